@@ -0,0 +1,443 @@
+package main
+
+import (
+	"fmt"
+	"math"
+
+	"golang.org/x/exp/rand"
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+// denseToSym copies a (numerically symmetric) Dense into a SymDense so it
+// can feed mat.Cholesky, same role as the manual build already used for
+// SigmaU in OLSEstimator.Estimate.
+func denseToSym(d *mat.Dense) *mat.SymDense {
+	n, _ := d.Dims()
+	data := make([]float64, n*n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			data[i*n+j] = d.At(i, j)
+		}
+	}
+	return mat.NewSymDense(n, data)
+}
+
+// BVAREstimator fits a VAR under a Minnesota-style Normal-Inverse-Wishart
+// conjugate prior. Select it via EstimationOptions.Prior = PriorMinnesota;
+// hyperparameters come from EstimationOptions.BVARHyper (or the defaults).
+//
+// Each equation is shrunk independently: coefficient (i,j) at lag l has
+// prior mean lambda1 (own first lag) or 0 (everything else), and prior
+// variance (lambda0*lambda1/l^lambda3)^2 for own lags or
+// (lambda0*lambda2/l^lambda3 * sigma_i/sigma_j)^2 for cross lags, where
+// sigma_i is the AR(1) residual std of series i. Deterministic columns are
+// left diffuse (no shrinkage).
+type BVAREstimator struct{}
+
+// BVARPosterior carries the sufficient statistics of the fitted NIW posterior
+// so PosteriorDraw can resample (A, SigmaU) pairs without refitting.
+type BVARPosterior struct {
+	// X, Yreg are the real-data regressor/response matrices the model was
+	// fit on (Treg x m and Treg x K).
+	X, Yreg *mat.Dense
+
+	// Prec holds the per-equation prior precision diagonal (length m) used
+	// to ridge-shrink that equation's coefficients.
+	Prec [][]float64
+	// Mean holds the per-equation prior mean vector (length m) that Prec
+	// shrinks toward, so PosteriorDraw's per-equation mean matches Estimate's.
+	Mean [][]float64
+
+	// S is the posterior IW scale matrix and Dof its degrees of freedom.
+	S   *mat.SymDense
+	Dof float64
+
+	detCols int
+	lags    int
+	k       int
+}
+
+// arResidualVariance fits a plain AR(1) to column k of Y and returns the
+// residual variance, used as the sigma_i in the Minnesota cross-lag formula.
+func arResidualVariance(Y *mat.Dense) []float64 {
+	T, K := Y.Dims()
+	sigma2 := make([]float64, K)
+
+	for k := 0; k < K; k++ {
+		if T < 3 {
+			sigma2[k] = 1.0
+			continue
+		}
+		n := T - 1
+		var sxx, sxy, sx, sy float64
+		for t := 1; t < T; t++ {
+			x := Y.At(t-1, k)
+			y := Y.At(t, k)
+			sx += x
+			sy += y
+			sxx += x * x
+			sxy += x * y
+		}
+		nf := float64(n)
+		denom := nf*sxx - sx*sx
+		phi := 0.0
+		if denom != 0 {
+			phi = (nf*sxy - sx*sy) / denom
+		}
+		var ssr float64
+		for t := 1; t < T; t++ {
+			resid := Y.At(t, k) - phi*Y.At(t-1, k)
+			ssr += resid * resid
+		}
+		if n > 1 {
+			sigma2[k] = ssr / float64(n-1)
+		}
+		if sigma2[k] <= 0 {
+			sigma2[k] = 1.0
+		}
+	}
+	return sigma2
+}
+
+// Estimate fits the Minnesota BVAR. opts.Prior is ignored here (the caller
+// picked this estimator already); it exists purely so BVAREstimator satisfies
+// the Estimator interface.
+func (e *BVAREstimator) Estimate(ts *TimeSeries, spec ModelSpec, opts EstimationOptions) (*ReducedFormVAR, error) {
+	if spec.HasExogenous {
+		return nil, fmt.Errorf("exogenous variables not supported yet")
+	}
+
+	hyper := DefaultBVARHyper()
+	if opts.BVARHyper != nil {
+		hyper = *opts.BVARHyper
+	}
+
+	X, Yreg, detCols, _, err := buildRegressors(ts, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	_, K := ts.Y.Dims()
+	p := spec.Lags
+	Treg, m := X.Dims()
+
+	sigma2 := arResidualVariance(ts.Y)
+
+	// Per-equation ridge shrinkage toward the Minnesota prior mean.
+	Bhat := mat.NewDense(m, K, nil)
+	prec := make([][]float64, K)
+	priorMean := make([][]float64, K)
+
+	for eq := 0; eq < K; eq++ {
+		precEq := make([]float64, m)
+		mean := make([]float64, m)
+
+		// Deterministic columns stay diffuse: zero precision, zero mean.
+		for c := 0; c < detCols; c++ {
+			precEq[c] = 0
+		}
+
+		col := detCols
+		for lag := 1; lag <= p; lag++ {
+			for j := 0; j < K; j++ {
+				var variance float64
+				if j == eq {
+					variance = pow2(hyper.Lambda0 * hyper.Lambda1 / powF(float64(lag), hyper.Lambda3))
+					mean[col] = 0
+					if lag == 1 {
+						mean[col] = hyper.Lambda1
+					}
+				} else {
+					ratio := sigmaRatio(sigma2, eq, j)
+					variance = pow2(hyper.Lambda0 * hyper.Lambda2 / powF(float64(lag), hyper.Lambda3) * ratio)
+					mean[col] = 0
+				}
+				if variance > 0 {
+					precEq[col] = 1.0 / variance
+				}
+				col++
+			}
+		}
+		prec[eq] = precEq
+		priorMean[eq] = mean
+
+		// Ridge solve: (X'X + diag(precEq)) a = X'y + diag(precEq)*mean
+		var xtx mat.Dense
+		xtx.Mul(X.T(), X)
+		for c := 0; c < m; c++ {
+			xtx.Set(c, c, xtx.At(c, c)+precEq[c])
+		}
+
+		y := Yreg.ColView(eq)
+		var xty mat.VecDense
+		xty.MulVec(X.T(), y)
+		for c := 0; c < m; c++ {
+			xty.SetVec(c, xty.AtVec(c)+precEq[c]*mean[c])
+		}
+
+		var a mat.VecDense
+		if err := a.SolveVec(&xtx, &xty); err != nil {
+			return nil, fmt.Errorf("BVAR ridge solve failed for equation %d: %v", eq, err)
+		}
+		for c := 0; c < m; c++ {
+			Bhat.Set(c, eq, a.AtVec(c))
+		}
+	}
+
+	// Split Bhat into C (deterministic) and A_j's, same layout as OLSEstimator.
+	var C *mat.Dense
+	if detCols > 0 {
+		C = mat.NewDense(K, detCols, nil)
+		for k := 0; k < K; k++ {
+			for d := 0; d < detCols; d++ {
+				C.Set(k, d, Bhat.At(d, k))
+			}
+		}
+	}
+
+	A := make([]*mat.Dense, p)
+	for j := 0; j < p; j++ {
+		Aj := mat.NewDense(K, K, nil)
+		rowOffset := detCols + j*K
+		for eq := 0; eq < K; eq++ {
+			for colVar := 0; colVar < K; colVar++ {
+				Aj.Set(eq, colVar, Bhat.At(rowOffset+colVar, eq))
+			}
+		}
+		A[j] = Aj
+	}
+
+	// Posterior scale: real-data SSR plus the prior misfit of the shrunk fit,
+	// the NIW scale matrix implied by the stacked dummy-observation prior.
+	var Yhat mat.Dense
+	Yhat.Mul(X, Bhat)
+	var U mat.Dense
+	U.Sub(Yreg, &Yhat)
+	var S mat.Dense
+	S.Mul(U.T(), &U)
+
+	for eq := 0; eq < K; eq++ {
+		var misfit float64
+		for c := 0; c < m; c++ {
+			if prec[eq][c] == 0 {
+				continue
+			}
+			d := Bhat.At(c, eq)
+			switch {
+			case c < detCols:
+				// diffuse, no contribution
+			default:
+				lag := (c-detCols)/K + 1
+				j := (c - detCols) % K
+				priorMean := 0.0
+				if j == eq && lag == 1 {
+					priorMean = hyper.Lambda1
+				}
+				diff := d - priorMean
+				misfit += prec[eq][c] * diff * diff
+			}
+		}
+		S.Set(eq, eq, S.At(eq, eq)+misfit)
+	}
+
+	df := float64(Treg - m)
+	if df <= 0 {
+		df = float64(Treg)
+	}
+
+	sData := make([]float64, K*K)
+	for i := 0; i < K; i++ {
+		for j := 0; j < K; j++ {
+			sData[i*K+j] = S.At(i, j) / df
+		}
+	}
+	SigmaU := mat.NewSymDense(K, sData)
+
+	post := &BVARPosterior{
+		X:       X,
+		Yreg:    Yreg,
+		Prec:    prec,
+		Mean:    priorMean,
+		S:       mat.NewSymDense(K, S.RawMatrix().Data),
+		Dof:     float64(Treg) + df,
+		detCols: detCols,
+		lags:    p,
+		k:       K,
+	}
+
+	rf := &ReducedFormVAR{
+		Model:          spec,
+		A:              A,
+		C:              C,
+		SigmaU:         SigmaU,
+		Posterior:      post,
+		residualsCache: mat.DenseCopyOf(&U),
+	}
+
+	return rf, nil
+}
+
+func pow2(x float64) float64 { return x * x }
+
+func powF(base, exp float64) float64 {
+	return math.Pow(base, exp)
+}
+
+func sigmaRatio(sigma2 []float64, i, j int) float64 {
+	if sigma2[j] == 0 {
+		return 0
+	}
+	return math.Sqrt(sigma2[i]) / math.Sqrt(sigma2[j])
+}
+
+// PosteriorDraw samples one (A, SigmaU) pair from the fitted NIW posterior:
+// SigmaU ~ InverseWishart(Dof, S) via a Bartlett decomposition, then each
+// equation's coefficients are drawn independently as
+// Normal(Ahat_i, SigmaU[i,i] * (X'X + Prec_i)^-1), matching the per-equation
+// ridge used to fit the posterior mean.
+func (rf *ReducedFormVAR) PosteriorDraw(rng *rand.Rand) ([]*mat.Dense, *mat.Dense, *mat.SymDense, error) {
+	post := rf.Posterior
+	if post == nil {
+		return nil, nil, nil, fmt.Errorf("model has no BVAR posterior (not estimated with BVAREstimator)")
+	}
+
+	K := post.k
+
+	SigmaU, err := sampleInverseWishart(post.S, post.Dof, rng)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("posterior draw: %v", err)
+	}
+
+	_, mCols := post.X.Dims()
+	Bdraw := mat.NewDense(mCols, K, nil)
+
+	var xtx mat.Dense
+	xtx.Mul(post.X.T(), post.X)
+
+	for eq := 0; eq < K; eq++ {
+		xtxEq := *mat.DenseCopyOf(&xtx)
+		for c := 0; c < mCols; c++ {
+			xtxEq.Set(c, c, xtxEq.At(c, c)+post.Prec[eq][c])
+		}
+		var cov mat.Dense
+		if err := cov.Inverse(&xtxEq); err != nil {
+			return nil, nil, nil, fmt.Errorf("posterior draw: equation %d covariance singular: %v", eq, err)
+		}
+		cov.Scale(SigmaU.At(eq, eq), &cov)
+
+		y := post.Yreg.ColView(eq)
+		var xty mat.VecDense
+		xty.MulVec(post.X.T(), y)
+		for c := 0; c < mCols; c++ {
+			xty.SetVec(c, xty.AtVec(c)+post.Prec[eq][c]*post.Mean[eq][c])
+		}
+
+		var meanVec mat.VecDense
+		meanVec.SolveVec(&xtxEq, &xty)
+
+		draw := sampleNormalVec(&meanVec, &cov, rng)
+		for c := 0; c < mCols; c++ {
+			Bdraw.Set(c, eq, draw.AtVec(c))
+		}
+	}
+
+	var C *mat.Dense
+	if post.detCols > 0 {
+		C = mat.NewDense(K, post.detCols, nil)
+		for k := 0; k < K; k++ {
+			for d := 0; d < post.detCols; d++ {
+				C.Set(k, d, Bdraw.At(d, k))
+			}
+		}
+	}
+
+	A := make([]*mat.Dense, post.lags)
+	for j := 0; j < post.lags; j++ {
+		Aj := mat.NewDense(K, K, nil)
+		rowOffset := post.detCols + j*K
+		for eq := 0; eq < K; eq++ {
+			for colVar := 0; colVar < K; colVar++ {
+				Aj.Set(eq, colVar, Bdraw.At(rowOffset+colVar, eq))
+			}
+		}
+		A[j] = Aj
+	}
+
+	return A, C, SigmaU, nil
+}
+
+// sampleInverseWishart draws SigmaU ~ InverseWishart(dof, S) via a Bartlett
+// decomposition of S^-1's Cholesky factor.
+func sampleInverseWishart(S *mat.SymDense, dof float64, rng *rand.Rand) (*mat.SymDense, error) {
+	K := S.SymmetricDim()
+
+	var Sinv mat.Dense
+	if err := Sinv.Inverse(S); err != nil {
+		return nil, fmt.Errorf("scale matrix not invertible: %v", err)
+	}
+	SinvSym := denseToSym(&Sinv)
+
+	var chol mat.Cholesky
+	if !chol.Factorize(SinvSym) {
+		return nil, fmt.Errorf("scale matrix not positive definite")
+	}
+	L := mat.NewTriDense(K, mat.Lower, nil)
+	chol.LTo(L)
+
+	// Bartlett decomposition: A is lower-triangular with chi(dof-i) on the
+	// diagonal and iid standard normals below it.
+	Adata := mat.NewDense(K, K, nil)
+	normal := distuv.Normal{Mu: 0, Sigma: 1, Src: rng}
+	for i := 0; i < K; i++ {
+		chi := distuv.ChiSquared{K: dof - float64(i), Src: rng}
+		Adata.Set(i, i, math.Sqrt(chi.Rand()))
+		for j := 0; j < i; j++ {
+			Adata.Set(i, j, normal.Rand())
+		}
+	}
+
+	var LA mat.Dense
+	LA.Mul(L, Adata)
+
+	var W mat.Dense
+	W.Mul(&LA, LA.T()) // W ~ Wishart(dof, Sinv)
+
+	var Winv mat.Dense
+	if err := Winv.Inverse(&W); err != nil {
+		return nil, fmt.Errorf("sampled Wishart draw singular: %v", err)
+	}
+
+	return denseToSym(&Winv), nil
+}
+
+// sampleNormalVec draws one vector from N(mean, cov) using cov's Cholesky factor.
+func sampleNormalVec(mean *mat.VecDense, cov *mat.Dense, rng *rand.Rand) *mat.VecDense {
+	n := mean.Len()
+	covSym := denseToSym(cov)
+
+	var chol mat.Cholesky
+	z := mat.NewVecDense(n, nil)
+	normal := distuv.Normal{Mu: 0, Sigma: 1, Src: rng}
+	for i := 0; i < n; i++ {
+		z.SetVec(i, normal.Rand())
+	}
+
+	if !chol.Factorize(covSym) {
+		// Covariance not PD (can happen at numerical extremes) - fall back to the mean.
+		out := mat.NewVecDense(n, nil)
+		for i := 0; i < n; i++ {
+			out.SetVec(i, mean.AtVec(i))
+		}
+		return out
+	}
+	L := mat.NewTriDense(n, mat.Lower, nil)
+	chol.LTo(L)
+
+	var Lz mat.VecDense
+	Lz.MulVec(L, z)
+
+	out := mat.NewVecDense(n, nil)
+	out.AddVec(mean, &Lz)
+	return out
+}
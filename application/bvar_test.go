@@ -0,0 +1,106 @@
+package main
+
+import (
+	"testing"
+
+	"golang.org/x/exp/rand"
+	"gonum.org/v1/gonum/mat"
+)
+
+// Same VAR(1) series as TestEstimate_SimpleVAR1_NoDeterministic. With a
+// Minnesota prior centered at phi=1 (Lambda1=1), the posterior mean should
+// sit between the data-implied 0.5 and the prior mean of 1.
+func TestBVAREstimate_ShrinksTowardPriorMean(t *testing.T) {
+	data := []float64{
+		1.0, 0.5, 0.25, 0.125, 0.0625, 0.03125, 0.015625,
+	}
+	T := len(data)
+	Y := mat.NewDense(T, 1, data)
+
+	ts := &TimeSeries{
+		Y:        Y,
+		VarNames: []string{"y"},
+	}
+
+	spec := ModelSpec{
+		Lags:          1,
+		Deterministic: DetNone,
+		HasExogenous:  false,
+	}
+
+	// Very tight prior (small Lambda0) should pull phi close to Lambda1.
+	opts := EstimationOptions{
+		Prior:     PriorMinnesota,
+		BVARHyper: &BVARHyper{Lambda0: 0.01, Lambda1: 1.0, Lambda2: 0.5, Lambda3: 1.0},
+	}
+
+	est := &BVAREstimator{}
+	rf, err := est.Estimate(ts, spec, opts)
+	if err != nil {
+		t.Fatalf("BVAR Estimate returned error: %v", err)
+	}
+
+	phiHat := rf.A[0].At(0, 0)
+	if phiHat <= 0.5 || phiHat > 1.0 {
+		t.Errorf("expected shrinkage to pull phi toward 1.0, got %v", phiHat)
+	}
+
+	if rf.Posterior == nil {
+		t.Fatalf("expected rf.Posterior to be populated for BVAR estimates")
+	}
+}
+
+// PosteriorDraw should produce positive-definite SigmaU draws that, averaged
+// over many draws, cluster near the posterior mean Estimate already reports.
+func TestPosteriorDraw_ClustersAroundPosteriorMeanWithValidSigmaU(t *testing.T) {
+	data := []float64{
+		1.0, 0.5, 0.25, 0.125, 0.0625, 0.03125, 0.015625,
+	}
+	T := len(data)
+	Y := mat.NewDense(T, 1, data)
+
+	ts := &TimeSeries{
+		Y:        Y,
+		VarNames: []string{"y"},
+	}
+
+	spec := ModelSpec{
+		Lags:          1,
+		Deterministic: DetNone,
+		HasExogenous:  false,
+	}
+
+	opts := EstimationOptions{
+		Prior:     PriorMinnesota,
+		BVARHyper: &BVARHyper{Lambda0: 0.01, Lambda1: 1.0, Lambda2: 0.5, Lambda3: 1.0},
+	}
+
+	est := &BVAREstimator{}
+	rf, err := est.Estimate(ts, spec, opts)
+	if err != nil {
+		t.Fatalf("BVAR Estimate returned error: %v", err)
+	}
+	phiMean := rf.A[0].At(0, 0)
+
+	rng := rand.New(rand.NewSource(1))
+	const nDraws = 500
+	var phiSum float64
+	for d := 0; d < nDraws; d++ {
+		A, _, SigmaU, err := rf.PosteriorDraw(rng)
+		if err != nil {
+			t.Fatalf("PosteriorDraw returned error on draw %d: %v", d, err)
+		}
+
+		var chol mat.Cholesky
+		if !chol.Factorize(SigmaU) {
+			t.Fatalf("draw %d: SigmaU is not positive definite: %v", d, SigmaU)
+		}
+
+		phiSum += A[0].At(0, 0)
+	}
+
+	phiDrawMean := phiSum / nDraws
+	if diff := phiDrawMean - phiMean; diff > 0.1 || diff < -0.1 {
+		t.Errorf("mean of %d posterior draws = %v, want close to posterior mean %v", nDraws, phiDrawMean, phiMean)
+	}
+}
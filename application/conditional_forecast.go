@@ -0,0 +1,140 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// ConditionalForecast produces a forecast path that is forced to hit
+// user-supplied values for a subset of variables over some prefix of the
+// horizon, while leaving every other variable (and every unconstrained
+// later period of a constrained variable) free.
+//
+// constraints[j] gives the required level of variable j for periods
+// 1..len(constraints[j]); a variable can be constrained for only part of the
+// horizon (e.g. fixed for 4 weeks, then left free) by supplying a shorter
+// slice than steps.
+//
+// Algorithm: take the unconditional Forecast as the baseline, express the
+// deviation of each constrained value from that baseline as a linear
+// combination of the reduced-form shocks e_1..e_steps via the MA
+// representation y_{T+h} = yhat_{T+h} + sum_{s=1}^{h} Psi_{h-s} e_s, then
+// solve for the minimum-norm shock sequence hitting every constraint via the
+// same SVD pseudoinverse OLSEstimator falls back on for singular systems.
+//
+// Returns the adjusted endogenous path (steps x K) and the implied shock
+// matrix (steps x K) so callers can see which shocks were needed.
+func (rf *ReducedFormVAR) ConditionalForecast(y0 *mat.Dense, steps int, constraints map[int][]float64) (*mat.Dense, *mat.Dense, error) {
+	if rf == nil || len(rf.A) == 0 {
+		return nil, nil, fmt.Errorf("VAR model not estimated")
+	}
+	if steps <= 0 {
+		return nil, nil, fmt.Errorf("steps must be > 0")
+	}
+
+	K, _ := rf.A[0].Dims()
+
+	unconditional, err := rf.Forecast(y0, steps, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for j, path := range constraints {
+		if j < 0 || j >= K {
+			return nil, nil, fmt.Errorf("constraint variable index %d out of range [0, %d)", j, K)
+		}
+		if len(path) > steps {
+			return nil, nil, fmt.Errorf("constraint on variable %d has %d periods, longer than the %d-step horizon", j, len(path), steps)
+		}
+	}
+
+	Psi := psiMatrices(rf.A, steps)
+
+	// Flatten constraints into ordered (varIndex, period, targetDeviation) rows.
+	type constraintRow struct {
+		varIdx int
+		period int // 1-indexed
+		target float64
+	}
+	var rows []constraintRow
+	for j, path := range constraints {
+		for t, level := range path {
+			period := t + 1
+			dev := level - unconditional.At(period-1, j)
+			rows = append(rows, constraintRow{varIdx: j, period: period, target: dev})
+		}
+	}
+	// Deterministic ordering so repeated calls are reproducible.
+	sort.Slice(rows, func(a, b int) bool {
+		if rows[a].period != rows[b].period {
+			return rows[a].period < rows[b].period
+		}
+		return rows[a].varIdx < rows[b].varIdx
+	})
+
+	nConstraints := len(rows)
+	nShocks := steps * K
+	if nConstraints > nShocks {
+		return nil, nil, fmt.Errorf("%d constrained values exceed the %d available shocks (%d steps x %d variables)", nConstraints, nShocks, steps, K)
+	}
+
+	if nConstraints == 0 {
+		return unconditional, mat.NewDense(steps, K, nil), nil
+	}
+
+	// R * vec(e) = target, where vec(e) stacks e_1..e_steps (each length K).
+	R := mat.NewDense(nConstraints, nShocks, nil)
+	target := make([]float64, nConstraints)
+
+	for r, row := range rows {
+		target[r] = row.target
+		for s := 1; s <= row.period; s++ {
+			lag := row.period - s
+			psi := Psi[lag]
+			colBase := (s - 1) * K
+			for k := 0; k < K; k++ {
+				R.Set(r, colBase+k, psi.At(row.varIdx, k))
+			}
+		}
+	}
+
+	var svd mat.SVD
+	if !svd.Factorize(R, mat.SVDFullU|mat.SVDFullV) {
+		return nil, nil, fmt.Errorf("conditional forecast: SVD factorization of constraint matrix failed")
+	}
+	rank := svd.Rank(1e-12)
+
+	var eVec mat.Dense
+	if rank == 0 {
+		eVec = *mat.NewDense(nShocks, 1, nil)
+	} else {
+		svd.SolveTo(&eVec, mat.NewDense(nConstraints, 1, target), rank)
+	}
+
+	shocks := mat.NewDense(steps, K, nil)
+	for s := 0; s < steps; s++ {
+		for k := 0; k < K; k++ {
+			shocks.Set(s, k, eVec.At(s*K+k, 0))
+		}
+	}
+
+	out := mat.NewDense(steps, K, nil)
+	for h := 0; h < steps; h++ {
+		for i := 0; i < K; i++ {
+			val := unconditional.At(h, i)
+			for s := 0; s <= h; s++ {
+				lag := h - s
+				var contrib float64
+				for k := 0; k < K; k++ {
+					contrib += Psi[lag].At(i, k) * shocks.At(s, k)
+				}
+				val += contrib
+			}
+			out.Set(h, i, val)
+		}
+	}
+
+	return out, shocks, nil
+}
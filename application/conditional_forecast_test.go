@@ -0,0 +1,57 @@
+package main
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// Scalar VAR(1): y_t = 0.5 y_{t-1}. Constraining period 1 to 2.0 (instead of
+// the unconditional 0.5) should be hit exactly by a single shock in period 1,
+// which then propagates into later unconstrained periods via A_1.
+func TestConditionalForecast_ScalarVAR1_HitsConstraint(t *testing.T) {
+	spec := ModelSpec{Lags: 1, Deterministic: DetNone}
+	A1 := mat.NewDense(1, 1, []float64{0.5})
+	rf := &ReducedFormVAR{Model: spec, A: []*mat.Dense{A1}}
+
+	y0 := mat.NewDense(1, 1, []float64{1.0})
+
+	constraints := map[int][]float64{0: {2.0}}
+
+	out, shocks, err := rf.ConditionalForecast(y0, 3, constraints)
+	if err != nil {
+		t.Fatalf("ConditionalForecast returned error: %v", err)
+	}
+
+	if !almostEqual(out.At(0, 0), 2.0, 1e-8) {
+		t.Errorf("out[0] = %v, want 2.0 (the constraint)", out.At(0, 0))
+	}
+
+	if !almostEqual(shocks.At(0, 0), 1.5, 1e-8) {
+		t.Errorf("shocks[0] = %v, want 1.5", shocks.At(0, 0))
+	}
+
+	// Period 2 is unconstrained: unconditional 0.25 plus the lagged effect
+	// of the period-1 shock via A_1.
+	want1 := 0.25 + 0.5*1.5
+	if !almostEqual(out.At(1, 0), want1, 1e-8) {
+		t.Errorf("out[1] = %v, want %v", out.At(1, 0), want1)
+	}
+}
+
+func TestConditionalForecast_TooManyConstraints(t *testing.T) {
+	spec := ModelSpec{Lags: 1, Deterministic: DetNone}
+	A1 := mat.NewDense(1, 1, []float64{0.5})
+	rf := &ReducedFormVAR{Model: spec, A: []*mat.Dense{A1}}
+
+	y0 := mat.NewDense(1, 1, []float64{1.0})
+
+	// 1 variable, 1 step => only 1 shock available; asking for 2 constrained
+	// values should error cleanly.
+	constraints := map[int][]float64{0: {2.0, 3.0}}
+
+	_, _, err := rf.ConditionalForecast(y0, 1, constraints)
+	if err == nil {
+		t.Fatalf("expected an error when constraints exceed the horizon, got nil")
+	}
+}
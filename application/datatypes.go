@@ -22,8 +22,13 @@ type TimeSeriesPoint struct {
 type TimeSeries struct {
 	// Matrix for data
 	Y *mat.Dense
-	// Tracks number of time points, basically rows
-	Time []float64
+	// Calendar date of each row, when loaded via LoadCSVToTimeSeriesDated.
+	// Nil for series loaded without an explicit date column, in which case
+	// row index is the only notion of "time".
+	Dates *DateIndex
+	// Contemporaneous exogenous regressors (T x m), e.g. temperature/humidity
+	// controls. Nil unless the model is fit with ModelSpec.HasExogenous.
+	X *mat.Dense
 	// List of variable Names
 	VarNames []string
 }
@@ -60,6 +65,24 @@ type ReducedFormVAR struct {
 
 	// Covariance of residuals (KxK)
 	SigmaU *mat.SymDense
+
+	// Exogenous-regressor coefficients (K x m), set only when Model.HasExogenous.
+	// Nil otherwise.
+	B *mat.Dense
+
+	// Posterior sufficient statistics, set only when this model was fit by
+	// BVAREstimator. Nil for OLS-estimated models.
+	Posterior *BVARPosterior
+
+	// residualsCache holds the fitted reduced-form residuals (Treg x K),
+	// populated by the estimator. Used internally by identification schemes
+	// (e.g. proxy-SVAR) that need residuals rather than just SigmaU.
+	residualsCache *mat.Dense
+
+	// initialLagsCache holds the first Lags rows of the series the model was
+	// fit on, the true initial conditions the residual bootstrap in
+	// IRFBands reconstructs synthetic series from.
+	initialLagsCache *mat.Dense
 }
 
 type ReducedForm interface {
@@ -70,19 +93,56 @@ type ReducedForm interface {
 	// Returns the error covariance
 	CovU() *mat.SymDense
 
-	// compute the forcasts for a given initial state
-	Forecast(y0 *mat.Dense, steps int) (*mat.Dense, error)
+	// compute the forcasts for a given initial state. futureX is required
+	// (steps x m) when the model was fit with HasExogenous, nil otherwise.
+	Forecast(y0 *mat.Dense, steps int, futureX *mat.Dense) (*mat.Dense, error)
 	// Simulates effect of one-time shock in 1 variable on all variables over time
 	IRF(horizon int, shockIndex int) (*mat.Dense, error)
 }
 
+// Prior selects which prior (if any) an estimator should apply.
+type Prior int
+
+// Prior Constants for estimation
+const (
+	PriorNone Prior = iota
+	PriorMinnesota
+)
+
 // EstimationOptions contains options like regularization strngth, priors, etc.
 type EstimationOptions struct {
 	// For standard VAr
 	UseGeneralizedLeastSquares bool
 
-	// EX: if BVAR is implemented
-	//Prior Prior
+	// Which prior to apply. Only consulted by BVAREstimator; OLSEstimator ignores it.
+	Prior Prior
+
+	// Minnesota prior hyperparameters, used when Prior == PriorMinnesota.
+	// Nil means "use DefaultBVARHyper()".
+	BVARHyper *BVARHyper
+
+	// Zero constraints on individual coefficients. Only consulted by
+	// OLSEstimator; nil means an unrestricted fit.
+	Restrictions *Restrictions
+}
+
+// BVARHyper holds the Minnesota prior hyperparameters for BVAREstimator.
+type BVARHyper struct {
+	// Lambda0 is the overall tightness of the prior (smaller = tighter shrinkage).
+	Lambda0 float64
+	// Lambda1 is the prior mean on each series' own first lag coefficient
+	// (1 for level data, 0 for differenced data).
+	Lambda1 float64
+	// Lambda2 scales the variance of cross-variable lag coefficients relative
+	// to own-variable lag coefficients.
+	Lambda2 float64
+	// Lambda3 controls how quickly prior variance decays as lag length grows.
+	Lambda3 float64
+}
+
+// DefaultBVARHyper returns the conventional Minnesota hyperparameter defaults.
+func DefaultBVARHyper() BVARHyper {
+	return BVARHyper{Lambda0: 0.2, Lambda1: 1.0, Lambda2: 0.5, Lambda3: 1.0}
 }
 
 type Estimator interface {
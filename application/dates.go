@@ -0,0 +1,324 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// Frequency is the calendar spacing between consecutive observations in a
+// DateIndex.
+type Frequency int
+
+// Frequency constants for DateIndex.
+const (
+	Daily Frequency = iota
+	Weekly
+	Monthly
+	Quarterly
+	Yearly
+)
+
+// ParseFrequency maps the user-facing frequency names used by
+// LoadCSVToTimeSeriesDated ("Daily", "Weekly", "Monthly", "Quarterly",
+// "Yearly", case insensitive, plus "Annual") to a Frequency. It rejects the
+// empty string; LoadCSVToTimeSeriesDated handles "" itself by inferring the
+// frequency from row spacing.
+func ParseFrequency(s string) (Frequency, error) {
+	switch strings.ToLower(s) {
+	case "daily":
+		return Daily, nil
+	case "weekly":
+		return Weekly, nil
+	case "monthly":
+		return Monthly, nil
+	case "quarterly":
+		return Quarterly, nil
+	case "yearly", "annual":
+		return Yearly, nil
+	default:
+		return 0, fmt.Errorf("unknown frequency %q", s)
+	}
+}
+
+// step advances t by n periods of f.
+func (f Frequency) step(t time.Time, n int) time.Time {
+	switch f {
+	case Daily:
+		return t.AddDate(0, 0, n)
+	case Weekly:
+		return t.AddDate(0, 0, 7*n)
+	case Monthly:
+		return t.AddDate(0, n, 0)
+	case Quarterly:
+		return t.AddDate(0, 3*n, 0)
+	case Yearly:
+		return t.AddDate(n, 0, 0)
+	default:
+		return t.AddDate(0, 0, n)
+	}
+}
+
+func (f Frequency) String() string {
+	switch f {
+	case Daily:
+		return "Daily"
+	case Weekly:
+		return "Weekly"
+	case Monthly:
+		return "Monthly"
+	case Quarterly:
+		return "Quarterly"
+	case Yearly:
+		return "Yearly"
+	default:
+		return "Unknown"
+	}
+}
+
+// DateIndex maps the integer row index of a TimeSeries to a calendar date,
+// assuming observations fall on a regular Freq grid starting at Start.
+type DateIndex struct {
+	Start time.Time
+	Freq  Frequency
+	N     int
+}
+
+// At returns the calendar date of row i. Panics if i is out of [0, N).
+func (d *DateIndex) At(i int) time.Time {
+	if i < 0 || i >= d.N {
+		panic(fmt.Sprintf("DateIndex.At: index %d out of range [0, %d)", i, d.N))
+	}
+	return d.Freq.step(d.Start, i)
+}
+
+// IndexOf returns the row index whose date equals t (compared at day
+// granularity), or -1 if t does not fall on this index's grid.
+func (d *DateIndex) IndexOf(t time.Time) int {
+	for i := 0; i < d.N; i++ {
+		if sameDay(d.Freq.step(d.Start, i), t) {
+			return i
+		}
+	}
+	return -1
+}
+
+func sameDay(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd
+}
+
+// Slice returns a new TimeSeries holding the rows whose dates fall in
+// [from, to] inclusive. Both ts and the result must have a non-nil Dates.
+func (ts *TimeSeries) Slice(from, to time.Time) (*TimeSeries, error) {
+	if ts.Dates == nil {
+		return nil, fmt.Errorf("Slice requires a date-indexed TimeSeries")
+	}
+
+	startIdx, endIdx := -1, -1
+	for i := 0; i < ts.Dates.N; i++ {
+		d := ts.Dates.At(i)
+		if (d.Equal(from) || d.After(from)) && startIdx == -1 {
+			startIdx = i
+		}
+		if d.Equal(to) || d.Before(to) {
+			endIdx = i
+		}
+	}
+	if startIdx == -1 || endIdx == -1 || endIdx < startIdx {
+		return nil, fmt.Errorf("Slice: no rows fall within [%s, %s]", from.Format("2006-01-02"), to.Format("2006-01-02"))
+	}
+
+	_, K := ts.Y.Dims()
+	n := endIdx - startIdx + 1
+	Y := mat.NewDense(n, K, nil)
+	for i := 0; i < n; i++ {
+		for k := 0; k < K; k++ {
+			Y.Set(i, k, ts.Y.At(startIdx+i, k))
+		}
+	}
+
+	return &TimeSeries{
+		Y:        Y,
+		VarNames: ts.VarNames,
+		Dates:    &DateIndex{Start: ts.Dates.At(startIdx), Freq: ts.Dates.Freq, N: n},
+	}, nil
+}
+
+// Subset is an alias for Slice, returning the rows whose dates fall in
+// [from, to] inclusive.
+func (ts *TimeSeries) Subset(from, to time.Time) (*TimeSeries, error) {
+	return ts.Slice(from, to)
+}
+
+// Lag returns a new TimeSeries whose row i holds ts's value from k periods
+// earlier, dropping the first k rows, which have no such history. If ts is
+// date-indexed, the result's Dates start k periods later than ts's, at the
+// same frequency, so row i still lines up with calendar date
+// ts.Dates.At(k+i).
+func (ts *TimeSeries) Lag(k int) (*TimeSeries, error) {
+	if k < 0 {
+		return nil, fmt.Errorf("Lag: k must be >= 0")
+	}
+	T, K := ts.Y.Dims()
+	if k >= T {
+		return nil, fmt.Errorf("Lag: k=%d must be less than the number of rows (%d)", k, T)
+	}
+
+	n := T - k
+	Y := mat.NewDense(n, K, nil)
+	for i := 0; i < n; i++ {
+		for col := 0; col < K; col++ {
+			Y.Set(i, col, ts.Y.At(i, col))
+		}
+	}
+
+	out := &TimeSeries{Y: Y, VarNames: ts.VarNames}
+	if ts.Dates != nil {
+		out.Dates = &DateIndex{Start: ts.Dates.At(k), Freq: ts.Dates.Freq, N: n}
+	}
+	return out, nil
+}
+
+// Diff returns the k-period difference series z_t = y_t - y_{t-k}, dropping
+// the first k rows. If ts is date-indexed, the result's Dates start k
+// periods later than ts's, at the same frequency.
+func (ts *TimeSeries) Diff(k int) (*TimeSeries, error) {
+	if k <= 0 {
+		return nil, fmt.Errorf("Diff: k must be > 0")
+	}
+	T, K := ts.Y.Dims()
+	if k >= T {
+		return nil, fmt.Errorf("Diff: k=%d must be less than the number of rows (%d)", k, T)
+	}
+
+	n := T - k
+	Y := mat.NewDense(n, K, nil)
+	for i := 0; i < n; i++ {
+		for col := 0; col < K; col++ {
+			Y.Set(i, col, ts.Y.At(k+i, col)-ts.Y.At(i, col))
+		}
+	}
+
+	out := &TimeSeries{Y: Y, VarNames: ts.VarNames}
+	if ts.Dates != nil {
+		out.Dates = &DateIndex{Start: ts.Dates.At(k), Freq: ts.Dates.Freq, N: n}
+	}
+	return out, nil
+}
+
+// Align inner-joins ts and other on their date indices, returning two new
+// TimeSeries restricted to the dates present in both (in date order). Both
+// inputs must be date-indexed and share the same Freq.
+func (ts *TimeSeries) Align(other *TimeSeries) (*TimeSeries, *TimeSeries, error) {
+	if ts.Dates == nil || other.Dates == nil {
+		return nil, nil, fmt.Errorf("Align requires both series to be date-indexed")
+	}
+	if ts.Dates.Freq != other.Dates.Freq {
+		return nil, nil, fmt.Errorf("Align: frequency mismatch (%s vs %s)", ts.Dates.Freq, other.Dates.Freq)
+	}
+
+	var common []time.Time
+	var leftIdx, rightIdx []int
+	for i := 0; i < ts.Dates.N; i++ {
+		d := ts.Dates.At(i)
+		j := other.Dates.IndexOf(d)
+		if j == -1 {
+			continue
+		}
+		common = append(common, d)
+		leftIdx = append(leftIdx, i)
+		rightIdx = append(rightIdx, j)
+	}
+	if len(common) == 0 {
+		return nil, nil, fmt.Errorf("Align: no overlapping dates between the two series")
+	}
+
+	build := func(src *TimeSeries, idx []int) *TimeSeries {
+		_, K := src.Y.Dims()
+		Y := mat.NewDense(len(idx), K, nil)
+		for i, srcRow := range idx {
+			for k := 0; k < K; k++ {
+				Y.Set(i, k, src.Y.At(srcRow, k))
+			}
+		}
+		return &TimeSeries{
+			Y:        Y,
+			VarNames: src.VarNames,
+			Dates:    &DateIndex{Start: common[0], Freq: src.Dates.Freq, N: len(idx)},
+		}
+	}
+
+	return build(ts, leftIdx), build(other, rightIdx), nil
+}
+
+// parseDateCell parses a single date column value. layout is a standard Go
+// reference-time layout (e.g. "2006-01-02"), or the special value "ISOWeek"
+// for strings like "2024-W03".
+func parseDateCell(s, layout string) (time.Time, error) {
+	if layout == "ISOWeek" {
+		return parseISOWeek(s)
+	}
+	return time.Parse(layout, s)
+}
+
+// parseISOWeek parses an ISO week string of the form "2006-W01" into the
+// Monday that starts that ISO week.
+func parseISOWeek(s string) (time.Time, error) {
+	parts := strings.SplitN(s, "-W", 2)
+	if len(parts) != 2 {
+		return time.Time{}, fmt.Errorf("invalid ISO week %q, want YYYY-Wnn", s)
+	}
+	year, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid ISO week year %q: %w", parts[0], err)
+	}
+	week, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid ISO week number %q: %w", parts[1], err)
+	}
+
+	// ISO week 1 is the week containing the year's first Thursday, i.e. the
+	// week containing January 4th.
+	jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, time.UTC)
+	isoWeekday := int(jan4.Weekday())
+	if isoWeekday == 0 {
+		isoWeekday = 7
+	}
+	week1Monday := jan4.AddDate(0, 0, -(isoWeekday - 1))
+	return week1Monday.AddDate(0, 0, (week-1)*7), nil
+}
+
+// inferFrequency guesses the Frequency from the spacing between the first
+// two parsed dates, matching it against each candidate's step function.
+func inferFrequency(dates []time.Time) (Frequency, error) {
+	if len(dates) < 2 {
+		return 0, fmt.Errorf("need at least 2 rows to infer a frequency")
+	}
+	for _, f := range []Frequency{Daily, Weekly, Monthly, Quarterly, Yearly} {
+		if sameDay(f.step(dates[0], 1), dates[1]) {
+			return f, nil
+		}
+	}
+	return 0, fmt.Errorf("could not infer frequency from row spacing between %s and %s",
+		dates[0].Format("2006-01-02"), dates[1].Format("2006-01-02"))
+}
+
+// validateFrequency checks that every consecutive pair in dates is exactly
+// one Freq step apart.
+func validateFrequency(dates []time.Time, f Frequency) error {
+	for i := 1; i < len(dates); i++ {
+		want := f.step(dates[i-1], 1)
+		if !sameDay(want, dates[i]) {
+			return fmt.Errorf(
+				"row %d: date %s does not follow row %d's date %s at %s frequency (expected %s)",
+				i, dates[i].Format("2006-01-02"), i-1, dates[i-1].Format("2006-01-02"), f, want.Format("2006-01-02"),
+			)
+		}
+	}
+	return nil
+}
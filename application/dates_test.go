@@ -0,0 +1,158 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeCSV(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "data.csv")
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("write fixture CSV: %v", err)
+	}
+	return path
+}
+
+func TestLoadCSVToTimeSeriesDated_Daily(t *testing.T) {
+	path := writeCSV(t, "Date,x\n2024-01-01,1.0\n2024-01-02,2.0\n2024-01-03,3.0\n")
+
+	ts, err := LoadCSVToTimeSeriesDated(path, "Date", "2006-01-02", "Daily")
+	if err != nil {
+		t.Fatalf("LoadCSVToTimeSeriesDated returned error: %v", err)
+	}
+	if ts.Dates == nil {
+		t.Fatalf("expected a populated Dates field")
+	}
+	if ts.Dates.Freq != Daily {
+		t.Errorf("Freq = %v, want Daily", ts.Dates.Freq)
+	}
+	want := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	if !sameDay(ts.Dates.At(1), want) {
+		t.Errorf("At(1) = %v, want %v", ts.Dates.At(1), want)
+	}
+}
+
+func TestLoadCSVToTimeSeriesDated_InferredWeeklyISOWeek(t *testing.T) {
+	path := writeCSV(t, "Date,x\n2024-W01,1.0\n2024-W02,2.0\n2024-W03,3.0\n")
+
+	ts, err := LoadCSVToTimeSeriesDated(path, "Date", "ISOWeek", "")
+	if err != nil {
+		t.Fatalf("LoadCSVToTimeSeriesDated returned error: %v", err)
+	}
+	if ts.Dates.Freq != Weekly {
+		t.Errorf("inferred Freq = %v, want Weekly", ts.Dates.Freq)
+	}
+}
+
+func TestLoadCSVToTimeSeriesDated_RejectsGaps(t *testing.T) {
+	path := writeCSV(t, "Date,x\n2024-01-01,1.0\n2024-01-05,2.0\n")
+
+	_, err := LoadCSVToTimeSeriesDated(path, "Date", "2006-01-02", "Daily")
+	if err == nil {
+		t.Fatalf("expected an error for a row gap inconsistent with the declared frequency")
+	}
+}
+
+func TestDateIndex_IndexOf(t *testing.T) {
+	d := &DateIndex{Start: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Freq: Daily, N: 5}
+	if idx := d.IndexOf(time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)); idx != 2 {
+		t.Errorf("IndexOf = %d, want 2", idx)
+	}
+	if idx := d.IndexOf(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)); idx != -1 {
+		t.Errorf("IndexOf out-of-range = %d, want -1", idx)
+	}
+}
+
+func TestTimeSeries_Align_InnerJoinsOnDates(t *testing.T) {
+	aPath := writeCSV(t, "Date,x\n2024-01-01,1.0\n2024-01-02,2.0\n2024-01-03,3.0\n")
+	bPath := writeCSV(t, "Date,y\n2024-01-02,20.0\n2024-01-03,30.0\n2024-01-04,40.0\n")
+
+	a, err := LoadCSVToTimeSeriesDated(aPath, "Date", "2006-01-02", "Daily")
+	if err != nil {
+		t.Fatalf("load a: %v", err)
+	}
+	b, err := LoadCSVToTimeSeriesDated(bPath, "Date", "2006-01-02", "Daily")
+	if err != nil {
+		t.Fatalf("load b: %v", err)
+	}
+
+	alignedA, alignedB, err := a.Align(b)
+	if err != nil {
+		t.Fatalf("Align returned error: %v", err)
+	}
+	if alignedA.Dates.N != 2 {
+		t.Fatalf("expected 2 overlapping rows, got %d", alignedA.Dates.N)
+	}
+	if !almostEqual(alignedA.Y.At(0, 0), 2.0, 1e-8) {
+		t.Errorf("alignedA row 0 = %v, want 2.0", alignedA.Y.At(0, 0))
+	}
+	if !almostEqual(alignedB.Y.At(0, 0), 20.0, 1e-8) {
+		t.Errorf("alignedB row 0 = %v, want 20.0", alignedB.Y.At(0, 0))
+	}
+}
+
+func TestTimeSeries_Lag_ShiftsDatesAndValues(t *testing.T) {
+	path := writeCSV(t, "Date,x\n2024-01-01,1.0\n2024-01-02,2.0\n2024-01-03,3.0\n2024-01-04,4.0\n")
+	ts, err := LoadCSVToTimeSeriesDated(path, "Date", "2006-01-02", "Daily")
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+
+	lagged, err := ts.Lag(2)
+	if err != nil {
+		t.Fatalf("Lag returned error: %v", err)
+	}
+	if lagged.Dates.N != 2 {
+		t.Fatalf("expected 2 rows after a 2-period lag of 4 rows, got %d", lagged.Dates.N)
+	}
+	want := time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)
+	if !sameDay(lagged.Dates.At(0), want) {
+		t.Errorf("lagged.Dates.At(0) = %v, want %v", lagged.Dates.At(0), want)
+	}
+	if !almostEqual(lagged.Y.At(0, 0), 1.0, 1e-8) {
+		t.Errorf("lagged.Y.At(0,0) = %v, want 1.0 (value from 2 periods earlier)", lagged.Y.At(0, 0))
+	}
+}
+
+func TestTimeSeries_Diff_ComputesKPeriodDifference(t *testing.T) {
+	path := writeCSV(t, "Date,x\n2024-01-01,1.0\n2024-01-02,3.0\n2024-01-03,6.0\n")
+	ts, err := LoadCSVToTimeSeriesDated(path, "Date", "2006-01-02", "Daily")
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+
+	diffed, err := ts.Diff(1)
+	if err != nil {
+		t.Fatalf("Diff returned error: %v", err)
+	}
+	if diffed.Dates.N != 2 {
+		t.Fatalf("expected 2 rows after a 1-period diff of 3 rows, got %d", diffed.Dates.N)
+	}
+	if !almostEqual(diffed.Y.At(0, 0), 2.0, 1e-8) {
+		t.Errorf("diffed.Y.At(0,0) = %v, want 2.0", diffed.Y.At(0, 0))
+	}
+	if !almostEqual(diffed.Y.At(1, 0), 3.0, 1e-8) {
+		t.Errorf("diffed.Y.At(1,0) = %v, want 3.0", diffed.Y.At(1, 0))
+	}
+}
+
+func TestTimeSeries_Subset_IsAnAliasForSlice(t *testing.T) {
+	path := writeCSV(t, "Date,x\n2024-01-01,1.0\n2024-01-02,2.0\n2024-01-03,3.0\n")
+	ts, err := LoadCSVToTimeSeriesDated(path, "Date", "2006-01-02", "Daily")
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+
+	from := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)
+	sub, err := ts.Subset(from, to)
+	if err != nil {
+		t.Fatalf("Subset returned error: %v", err)
+	}
+	if sub.Dates.N != 2 {
+		t.Fatalf("expected 2 rows, got %d", sub.Dates.N)
+	}
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// y_t = 0.5 y_{t-1} + 2.0 x_t, no noise, so OLS should recover both
+// coefficients almost exactly.
+func buildExogenousFixture() (*TimeSeries, ModelSpec) {
+	x := []float64{1.0, 0.5, -1.0, 2.0, 0.0, 1.5, -0.5, 1.0}
+	y := make([]float64, len(x))
+	y[0] = 1.0
+	for t := 1; t < len(x); t++ {
+		y[t] = 0.5*y[t-1] + 2.0*x[t]
+	}
+
+	ts := &TimeSeries{
+		Y:        mat.NewDense(len(y), 1, y),
+		X:        mat.NewDense(len(x), 1, x),
+		VarNames: []string{"y"},
+	}
+	spec := ModelSpec{Lags: 1, Deterministic: DetNone, HasExogenous: true}
+	return ts, spec
+}
+
+func TestOLSEstimate_RecoversExogenousCoefficient(t *testing.T) {
+	ts, spec := buildExogenousFixture()
+
+	rf, err := (&OLSEstimator{}).Estimate(ts, spec, EstimationOptions{})
+	if err != nil {
+		t.Fatalf("Estimate returned error: %v", err)
+	}
+	if rf.B == nil {
+		t.Fatalf("expected a non-nil B (exogenous coefficients)")
+	}
+	if rows, cols := rf.B.Dims(); rows != 1 || cols != 1 {
+		t.Fatalf("B dims = %dx%d, want 1x1", rows, cols)
+	}
+	if !almostEqual(rf.B.At(0, 0), 2.0, 1e-6) {
+		t.Errorf("B[0,0] = %v, want ~2.0", rf.B.At(0, 0))
+	}
+	if !almostEqual(rf.A[0].At(0, 0), 0.5, 1e-6) {
+		t.Errorf("A_1[0,0] = %v, want ~0.5", rf.A[0].At(0, 0))
+	}
+}
+
+func TestEstimate_MissingExogenousData(t *testing.T) {
+	spec := ModelSpec{Lags: 1, Deterministic: DetNone, HasExogenous: true}
+	ts := &TimeSeries{
+		Y:        mat.NewDense(3, 1, []float64{1.0, 0.5, 0.25}),
+		VarNames: []string{"y"},
+	}
+
+	_, err := (&OLSEstimator{}).Estimate(ts, spec, EstimationOptions{})
+	if err == nil {
+		t.Fatalf("expected an error when HasExogenous but TimeSeries.X is nil")
+	}
+}
+
+func TestForecast_RequiresFutureXWhenExogenous(t *testing.T) {
+	ts, spec := buildExogenousFixture()
+	rf, err := (&OLSEstimator{}).Estimate(ts, spec, EstimationOptions{})
+	if err != nil {
+		t.Fatalf("Estimate returned error: %v", err)
+	}
+
+	if _, err := rf.Forecast(ts.Y, 2, nil); err == nil {
+		t.Fatalf("expected an error when futureX is missing")
+	}
+
+	badX := mat.NewDense(1, 1, []float64{1.0}) // wrong row count for steps=2
+	if _, err := rf.Forecast(ts.Y, 2, badX); err == nil {
+		t.Fatalf("expected an error when futureX is mis-sized")
+	}
+}
+
+// buildExogenousFixtureK2 is buildExogenousFixture's two-variable sibling:
+// y1_t = 0.5 y1_{t-1} + 0.3 y2_{t-1} + 2.0 x_t
+// y2_t = 0.2 y1_{t-1} + 0.4 y2_{t-1} + 1.0 x_t
+// K=2 so GrangerCausalityMatrix has an actual off-diagonal pair, exercising
+// the exogenous-aware restricted/unrestricted SSR logic GrangerCausality
+// adds, unlike the K=1 buildExogenousFixture where every cell is diagonal.
+func buildExogenousFixtureK2() (*TimeSeries, ModelSpec) {
+	x := []float64{1.0, 0.5, -1.0, 2.0, 0.0, 1.5, -0.5, 1.0, 0.75, -0.25}
+	n := len(x)
+	y1 := make([]float64, n)
+	y2 := make([]float64, n)
+	y1[0], y2[0] = 1.0, -0.5
+	for t := 1; t < n; t++ {
+		y1[t] = 0.5*y1[t-1] + 0.3*y2[t-1] + 2.0*x[t]
+		y2[t] = 0.2*y1[t-1] + 0.4*y2[t-1] + 1.0*x[t]
+	}
+
+	Y := mat.NewDense(n, 2, nil)
+	for t := 0; t < n; t++ {
+		Y.Set(t, 0, y1[t])
+		Y.Set(t, 1, y2[t])
+	}
+
+	ts := &TimeSeries{
+		Y:        Y,
+		X:        mat.NewDense(n, 1, x),
+		VarNames: []string{"y1", "y2"},
+	}
+	spec := ModelSpec{Lags: 1, Deterministic: DetNone, HasExogenous: true}
+	return ts, spec
+}
+
+func TestGrangerCausalityMatrix_WithExogenousControl(t *testing.T) {
+	ts, spec := buildExogenousFixtureK2()
+	rf, err := (&OLSEstimator{}).Estimate(ts, spec, EstimationOptions{})
+	if err != nil {
+		t.Fatalf("Estimate returned error: %v", err)
+	}
+
+	// K=2, so the (0,1) and (1,0) cells actually run GrangerCausality's
+	// exogenous-aware restricted/unrestricted SSR logic instead of being
+	// skipped as self-causality.
+	gcMatrix, err := rf.GrangerCausalityMatrix(ts)
+	if err != nil {
+		t.Fatalf("GrangerCausalityMatrix returned error: %v", err)
+	}
+	if len(gcMatrix) != 2 || len(gcMatrix[0]) != 2 {
+		t.Fatalf("expected a 2x2 result matrix, got %dx%d", len(gcMatrix), len(gcMatrix[0]))
+	}
+	if gcMatrix[0][0] != nil || gcMatrix[1][1] != nil {
+		t.Fatalf("expected diagonal cells to stay nil (no self-causality test)")
+	}
+	if gcMatrix[0][1] == nil || gcMatrix[1][0] == nil {
+		t.Fatalf("expected both off-diagonal cells to hold a GrangerCausality result")
+	}
+}
+
+func TestForecast_UsesFutureXPath(t *testing.T) {
+	ts, spec := buildExogenousFixture()
+	rf, err := (&OLSEstimator{}).Estimate(ts, spec, EstimationOptions{})
+	if err != nil {
+		t.Fatalf("Estimate returned error: %v", err)
+	}
+
+	futureX := mat.NewDense(2, 1, []float64{1.0, -1.0})
+	fcst, err := rf.Forecast(ts.Y, 2, futureX)
+	if err != nil {
+		t.Fatalf("Forecast returned error: %v", err)
+	}
+
+	_, K := ts.Y.Dims()
+	lastY := ts.Y.At(ts.Y.RawMatrix().Rows-1, K-1)
+
+	want0 := rf.A[0].At(0, 0)*lastY + rf.B.At(0, 0)*1.0
+	want1 := rf.A[0].At(0, 0)*want0 + rf.B.At(0, 0)*(-1.0)
+
+	if !almostEqual(fcst.At(0, 0), want0, 1e-8) {
+		t.Errorf("fcst[0] = %v, want %v", fcst.At(0, 0), want0)
+	}
+	if !almostEqual(fcst.At(1, 0), want1, 1e-8) {
+		t.Errorf("fcst[1] = %v, want %v", fcst.At(1, 0), want1)
+	}
+}
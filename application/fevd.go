@@ -0,0 +1,79 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// FEVD returns, for each horizon h=1..horizon, a K x K matrix whose (i,j)
+// entry is the share of variable i's h-step forecast error variance
+// explained by a Cholesky-orthogonalized shock to variable j, using the same
+// ordering and fallback IRF uses.
+func (rf *ReducedFormVAR) FEVD(horizon int) ([]*mat.Dense, error) {
+	if rf == nil || len(rf.A) == 0 {
+		return nil, fmt.Errorf("VAR model not estimated")
+	}
+	if horizon <= 0 {
+		return nil, fmt.Errorf("horizon must be > 0")
+	}
+
+	K, _ := rf.A[0].Dims()
+	L := choleskyImpact(rf.SigmaU, K)
+	Psi := psiMatrices(rf.A, horizon)
+	return fevdFromImpact(Psi, L, K, horizon), nil
+}
+
+// OutputFEVDToCSV writes an FEVD result with columns (Horizon, Variable,
+// ShockIn_X1, ShockIn_X2, ...), one row per (horizon, variable) pair, in the
+// style of OutputIRFAnalysisToCSV and OutputForecastsToCSV.
+func (rf *ReducedFormVAR) OutputFEVDToCSV(path string, fevd []*mat.Dense, varNames []string) error {
+	if len(fevd) == 0 {
+		return fmt.Errorf("no FEVD data to write")
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	defer writer.Flush()
+
+	K, _ := fevd[0].Dims()
+
+	header := []string{"Horizon", "Variable"}
+	for j := 0; j < K; j++ {
+		if len(varNames) == K {
+			header = append(header, "ShockIn_"+varNames[j])
+		} else {
+			header = append(header, fmt.Sprintf("ShockIn_Var%d", j+1))
+		}
+	}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for h, mtx := range fevd {
+		for i := 0; i < K; i++ {
+			var varName string
+			if len(varNames) == K {
+				varName = varNames[i]
+			} else {
+				varName = fmt.Sprintf("Var%d", i+1)
+			}
+
+			record := []string{fmt.Sprintf("%d", h+1), varName}
+			for j := 0; j < K; j++ {
+				record = append(record, fmt.Sprintf("%f", mtx.At(i, j)))
+			}
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
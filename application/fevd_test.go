@@ -0,0 +1,68 @@
+package main
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// Scalar VAR(1): with K=1 there's only one shock, so it must explain 100% of
+// the forecast error variance at every horizon.
+func TestFEVD_ScalarVAR1_ExplainsAllVariance(t *testing.T) {
+	spec := ModelSpec{Lags: 1, Deterministic: DetNone}
+	A1 := mat.NewDense(1, 1, []float64{0.5})
+	SigmaU := mat.NewSymDense(1, []float64{2.0})
+	rf := &ReducedFormVAR{Model: spec, A: []*mat.Dense{A1}, SigmaU: SigmaU}
+
+	fevd, err := rf.FEVD(5)
+	if err != nil {
+		t.Fatalf("FEVD returned error: %v", err)
+	}
+	if len(fevd) != 5 {
+		t.Fatalf("expected 5 horizons, got %d", len(fevd))
+	}
+	for h, mtx := range fevd {
+		if !almostEqual(mtx.At(0, 0), 1.0, 1e-8) {
+			t.Errorf("h=%d: FEVD[0,0] = %v, want 1.0", h, mtx.At(0, 0))
+		}
+	}
+}
+
+// A 2-variable VAR with a cross-lag link (variable 2 feeds variable 1) and
+// correlated residuals: at every horizon, each variable's shares across the
+// two shocks should sum to 1.
+func TestFEVD_RowsSumToOne(t *testing.T) {
+	spec := ModelSpec{Lags: 1, Deterministic: DetNone}
+	A1 := mat.NewDense(2, 2, []float64{
+		0.3, 0.1,
+		0.0, 0.4,
+	})
+	SigmaU := mat.NewSymDense(2, []float64{
+		1.0, 0.2,
+		0.2, 1.0,
+	})
+	rf := &ReducedFormVAR{Model: spec, A: []*mat.Dense{A1}, SigmaU: SigmaU}
+
+	fevd, err := rf.FEVD(4)
+	if err != nil {
+		t.Fatalf("FEVD returned error: %v", err)
+	}
+	for h, mtx := range fevd {
+		for i := 0; i < 2; i++ {
+			sum := mtx.At(i, 0) + mtx.At(i, 1)
+			if !almostEqual(sum, 1.0, 1e-8) {
+				t.Errorf("h=%d row %d sums to %v, want 1.0", h, i, sum)
+			}
+		}
+	}
+}
+
+func TestFEVD_RejectsNonPositiveHorizon(t *testing.T) {
+	spec := ModelSpec{Lags: 1, Deterministic: DetNone}
+	A1 := mat.NewDense(1, 1, []float64{0.5})
+	rf := &ReducedFormVAR{Model: spec, A: []*mat.Dense{A1}}
+
+	if _, err := rf.FEVD(0); err == nil {
+		t.Fatalf("expected an error for horizon <= 0")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math"
 	"os"
+	"time"
 
 	"gonum.org/v1/gonum/mat"
 	"gonum.org/v1/gonum/stat/distuv"
@@ -23,11 +24,12 @@ func (rf *ReducedFormVAR) CovU() *mat.SymDense { return rf.SigmaU }
 // Forecasat produces multi-step ahead forecases given the historical data of yHist.
 // yHist: T x K (rows: time, cols: variables). Only last p rows are used as lags.
 // steps: number of steps ahead to forecast
+// futureX: steps x m exogenous path, required when rf.Model.HasExogenous (nil otherwise)
 // Returns: Steps xK matrix of forecasts
 // HOW TO USE:
 // rf, _ := (&OLSEstimator{}).Estimate(ts, spec, EstimationOptions{})
-// fcst, err := rf.Forecast(ts.Y, 10) //10-step ahead forecast
-func (rf *ReducedFormVAR) Forecast(yHist *mat.Dense, steps int) (*mat.Dense, error) {
+// fcst, err := rf.Forecast(ts.Y, 10, nil) //10-step ahead forecast
+func (rf *ReducedFormVAR) Forecast(yHist *mat.Dense, steps int, futureX *mat.Dense) (*mat.Dense, error) {
 	if rf == nil || len(rf.A) == 0 {
 		return nil, fmt.Errorf("VAR model not estimated")
 	}
@@ -41,6 +43,19 @@ func (rf *ReducedFormVAR) Forecast(yHist *mat.Dense, steps int) (*mat.Dense, err
 		return nil, fmt.Errorf("lags must be > 0 to forecast")
 	}
 
+	if rf.Model.HasExogenous {
+		if futureX == nil {
+			return nil, fmt.Errorf("model was fit with HasExogenous; futureX is required")
+		}
+		fxRows, fxCols := futureX.Dims()
+		_, wantCols := rf.B.Dims()
+		if fxRows != steps || fxCols != wantCols {
+			return nil, fmt.Errorf("futureX must be %d x %d (steps x exogenous vars), got %d x %d", steps, wantCols, fxRows, fxCols)
+		}
+	} else if futureX != nil {
+		return nil, fmt.Errorf("futureX given but model was not fit with HasExogenous")
+	}
+
 	// dimensions of yHist, T rows, K cols
 	T, K := yHist.Dims()
 	if T < p {
@@ -107,6 +122,14 @@ func (rf *ReducedFormVAR) Forecast(yHist *mat.Dense, steps int) (*mat.Dense, err
 				}
 			}
 
+			// exogenous part: B * x_t
+			if rf.Model.HasExogenous {
+				_, mExog := rf.B.Dims()
+				for e := 0; e < mExog; e++ {
+					val += rf.B.At(eq, e) * futureX.At(step, e)
+				}
+			}
+
 			// Sets each row of the forecast with the current value at each column
 			out.Set(row, eq, val)
 		}
@@ -162,19 +185,25 @@ func (rf *ReducedFormVAR) IRF(horizon int, shockIndex int) (*mat.Dense, error) {
 		shock[shockIndex] = 1.0
 	}
 
-	// Moving-average coeff matrix Psi_h
+	Psi := psiMatrices(rf.A, horizon)
+	return irfFromImpact(Psi, shock), nil
+}
+
+// psiMatrices computes the VAR(p) moving-average coefficients Psi_0..Psi_{horizon-1}
+// (Psi_0 = I_K) from the companion recursion Psi_h = sum_{j=1..min(h,p)} A_j * Psi_{h-j}.
+// Shared by IRF, StructuralIRF and FEVD so the recursion only lives in one place.
+func psiMatrices(A []*mat.Dense, horizon int) []*mat.Dense {
+	K, _ := A[0].Dims()
+	p := len(A)
+
 	Psi := make([]*mat.Dense, horizon)
 
-	// Psi_0 = I_K, makes matrix using mat
 	Idata := make([]float64, K*K)
-
 	for i := 0; i < K; i++ {
 		Idata[i*K+i] = 1.0
 	}
-	// makes a new identity matrix
 	Psi[0] = mat.NewDense(K, K, Idata)
 
-	// Recursively computes Psi_h
 	for h := 1; h < horizon; h++ {
 		M := mat.NewDense(K, K, nil)
 		maxLag := p
@@ -183,26 +212,100 @@ func (rf *ReducedFormVAR) IRF(horizon int, shockIndex int) (*mat.Dense, error) {
 		}
 		for j := 1; j <= maxLag; j++ {
 			var tmp mat.Dense
-			tmp.Mul(rf.A[j-1], Psi[h-j]) // A_j * Psi_{h-j}
+			tmp.Mul(A[j-1], Psi[h-j]) // A_j * Psi_{h-j}
 			M.Add(M, &tmp)
 		}
 		Psi[h] = M
 	}
+	return Psi
+}
 
-	// IRF[h] = Psi_h * shock
+// irfFromImpact applies a single structural impact vector (a column of the
+// structural B matrix, or the Cholesky shock used by IRF) to the Psi_h
+// sequence, returning the horizon x K impulse response matrix.
+func irfFromImpact(Psi []*mat.Dense, impact []float64) *mat.Dense {
+	horizon := len(Psi)
+	K := len(impact)
 
 	irf := mat.NewDense(horizon, K, nil)
-	shockVec := mat.NewVecDense(K, shock)
+	impactVec := mat.NewVecDense(K, impact)
 
 	for h := 0; h < horizon; h++ {
 		var resp mat.VecDense
-		resp.MulVec(Psi[h], shockVec)
+		resp.MulVec(Psi[h], impactVec)
 		for i := 0; i < K; i++ {
 			irf.Set(h, i, resp.AtVec(i))
 		}
 	}
 
-	return irf, nil
+	return irf
+}
+
+// fevdFromImpact computes, for each horizon h=1..horizon, a K x K matrix
+// whose (i,j) entry is the share of variable i's h-step forecast error
+// variance explained by structural shock j, given the MA coefficients Psi
+// and a K x K contemporaneous impact matrix (the Cholesky factor for IRF, or
+// an identified structural B). Shared by ReducedFormVAR.FEVD and
+// StructuralVAR.FEVD.
+func fevdFromImpact(Psi []*mat.Dense, impact mat.Matrix, K, horizon int) []*mat.Dense {
+	// Theta_h = Psi_h * impact
+	Theta := make([]*mat.Dense, horizon)
+	for h := 0; h < horizon; h++ {
+		var th mat.Dense
+		th.Mul(Psi[h], impact)
+		Theta[h] = &th
+	}
+
+	// numerator[i][j] accumulates Sum_{s=0..h-1} Theta_s[i,j]^2
+	numerator := make([][]float64, K)
+	for i := range numerator {
+		numerator[i] = make([]float64, K)
+	}
+
+	result := make([]*mat.Dense, horizon)
+	for h := 0; h < horizon; h++ {
+		for i := 0; i < K; i++ {
+			for j := 0; j < K; j++ {
+				v := Theta[h].At(i, j)
+				numerator[i][j] += v * v
+			}
+		}
+
+		fevd := mat.NewDense(K, K, nil)
+		for i := 0; i < K; i++ {
+			var denom float64
+			for j := 0; j < K; j++ {
+				denom += numerator[i][j]
+			}
+			for j := 0; j < K; j++ {
+				if denom > 0 {
+					fevd.Set(i, j, numerator[i][j]/denom)
+				}
+			}
+		}
+		result[h] = fevd
+	}
+
+	return result
+}
+
+// choleskyImpact returns the lower Cholesky factor L of SigmaU (SigmaU = L
+// L^T), the recursive ordering IRF and FEVD use as their identifying impact
+// matrix. Falls back to the identity when SigmaU is nil or not positive
+// definite, matching IRF's existing fallback.
+func choleskyImpact(SigmaU *mat.SymDense, K int) *mat.TriDense {
+	L := mat.NewTriDense(K, mat.Lower, nil)
+	if SigmaU != nil {
+		var chol mat.Cholesky
+		if chol.Factorize(SigmaU) {
+			chol.LTo(L)
+			return L
+		}
+	}
+	for i := 0; i < K; i++ {
+		L.SetTri(i, i, 1.0)
+	}
+	return L
 }
 
 // Run IRF for all variables to look for changes in varible var, then compile results
@@ -239,7 +342,13 @@ func (rf *ReducedFormVAR) RunIRFAnalysis(varIndex int, horizon int) (map[int][]f
 	return results, nil
 }
 
-func (rf *ReducedFormVAR) OutputIRFAnalysisToCSV(path string, analysis map[int][]float64, varNames []string) error {
+// OutputIRFAnalysisToCSV writes the per-shock response series from
+// RunIRFAnalysis, one row per horizon. dates is optional: when nil, rows are
+// labeled by integer horizon; when non-nil (typically ts.Dates from a series
+// loaded via LoadCSVToTimeSeriesDated), the "Horizon" column is replaced by
+// the calendar date each horizon step lands on, walking forward from the
+// series' last observed date at dates.Freq.
+func (rf *ReducedFormVAR) OutputIRFAnalysisToCSV(path string, analysis map[int][]float64, varNames []string, dates *DateIndex) error {
 	file, err := os.Create(path)
 	if err != nil {
 		return err
@@ -252,7 +361,12 @@ func (rf *ReducedFormVAR) OutputIRFAnalysisToCSV(path string, analysis map[int][
 	defer writer.Flush() // Ensure all buffered data is written
 
 	// Write header
-	header := []string{"Horizon"}
+	var header []string
+	if dates != nil {
+		header = []string{"Date"}
+	} else {
+		header = []string{"Horizon"}
+	}
 	for shockIdx := range analysis {
 		var varName string
 		if len(varNames) == len(analysis) {
@@ -273,9 +387,19 @@ func (rf *ReducedFormVAR) OutputIRFAnalysisToCSV(path string, analysis map[int][
 		break
 	}
 
+	var lastObserved time.Time
+	if dates != nil {
+		lastObserved = dates.At(dates.N - 1)
+	}
+
 	// Write data rows
 	for h := 0; h < horizon; h++ {
-		record := []string{fmt.Sprintf("%d", h)}
+		var record []string
+		if dates != nil {
+			record = []string{dates.Freq.step(lastObserved, h+1).Format("2006-01-02")}
+		} else {
+			record = []string{fmt.Sprintf("%d", h)}
+		}
 		for shockIdx := range analysis {
 			record = append(record, fmt.Sprintf("%f", analysis[shockIdx][h]))
 		}
@@ -286,7 +410,13 @@ func (rf *ReducedFormVAR) OutputIRFAnalysisToCSV(path string, analysis map[int][
 	return nil
 }
 
-func (rf *ReducedFormVAR) OutputForecastsToCSV(path string, fc *mat.Dense, varNames []string) error {
+// OutputForecastsToCSV writes a forecast matrix (steps x K) to path, one row
+// per forecast step. dates is optional: when nil, rows carry no time label
+// (matching the plain Forecast output); when non-nil (typically ts.Dates
+// from a series loaded via LoadCSVToTimeSeriesDated), a leading "Date" column
+// gives the calendar date of each step, walking forward from the series'
+// last observed date at dates.Freq.
+func (rf *ReducedFormVAR) OutputForecastsToCSV(path string, fc *mat.Dense, varNames []string, dates *DateIndex) error {
 
 	rows, cols := fc.Dims()
 
@@ -300,24 +430,40 @@ func (rf *ReducedFormVAR) OutputForecastsToCSV(path string, fc *mat.Dense, varNa
 	writer := csv.NewWriter(file)
 	defer writer.Flush() // Ensure all buffered data is written
 
+	dateCol := 0
+	if dates != nil {
+		dateCol = 1
+	}
+
 	// Write header
-	header := make([]string, cols)
+	header := make([]string, cols+dateCol)
+	if dates != nil {
+		header[0] = "Date"
+	}
 	for j := 0; j < cols; j++ {
 		if len(varNames) == cols {
-			header[j] = varNames[j]
+			header[j+dateCol] = varNames[j]
 		} else {
-			header[j] = fmt.Sprintf("Var%d", j+1)
+			header[j+dateCol] = fmt.Sprintf("Var%d", j+1)
 		}
 	}
 	if err := writer.Write(header); err != nil {
 		return err
 	}
 
+	var lastObserved time.Time
+	if dates != nil {
+		lastObserved = dates.At(dates.N - 1)
+	}
+
 	// Write data rows
 	for i := 0; i < rows; i++ {
-		record := make([]string, cols)
+		record := make([]string, cols+dateCol)
+		if dates != nil {
+			record[0] = dates.Freq.step(lastObserved, i+1).Format("2006-01-02")
+		}
 		for j := 0; j < cols; j++ {
-			record[j] = fmt.Sprintf("%f", fc.At(i, j))
+			record[j+dateCol] = fmt.Sprintf("%f", fc.At(i, j))
 		}
 		if err := writer.Write(record); err != nil {
 			return err
@@ -326,31 +472,44 @@ func (rf *ReducedFormVAR) OutputForecastsToCSV(path string, fc *mat.Dense, varNa
 	return nil
 }
 
-// --- OLS IMPLEMENTATION ---
-func (e *OLSEstimator) Estimate(ts *TimeSeries, spec ModelSpec, opts EstimationOptions) (*ReducedFormVAR, error) {
+// buildRegressors lays out the standard VAR regressor matrix X (Treg x m)
+// and response matrix Yreg (Treg x K) shared by every estimator: deterministic
+// columns first (const, then trend), followed by the p lag blocks
+// [y_{t-1}, y_{t-2}, ..., y_{t-p}], followed by the exogenous columns (if
+// spec.HasExogenous) taken contemporaneously from ts.X. detCols tells callers
+// how many of the leading columns of X are deterministic rather than lagged
+// Y's; exogCols tells them how many of the trailing columns are exogenous.
+func buildRegressors(ts *TimeSeries, spec ModelSpec) (X, Yreg *mat.Dense, detCols, exogCols int, err error) {
 	if ts == nil || ts.Y == nil {
-		return nil, fmt.Errorf("time series data not provided")
+		return nil, nil, 0, 0, fmt.Errorf("time series data not provided")
 	}
 
 	T, K := ts.Y.Dims()
 	p := spec.Lags
 
 	if p <= 0 {
-		return nil, fmt.Errorf("lags must be > 0")
+		return nil, nil, 0, 0, fmt.Errorf("lags must be > 0")
 	}
 
 	if T <= p {
-		return nil, fmt.Errorf("need at least p+1 observations: p = %d, T = %d", p, T)
+		return nil, nil, 0, 0, fmt.Errorf("need at least p+1 observations: p = %d, T = %d", p, T)
 	}
+
 	if spec.HasExogenous {
-		return nil, fmt.Errorf("exogenous variables not supported yet")
+		if ts.X == nil {
+			return nil, nil, 0, 0, fmt.Errorf("spec.HasExogenous is true but TimeSeries.X is nil")
+		}
+		Tx, mx := ts.X.Dims()
+		if Tx != T {
+			return nil, nil, 0, 0, fmt.Errorf("TimeSeries.X has %d rows, want %d to match TimeSeries.Y", Tx, T)
+		}
+		exogCols = mx
 	}
 
-	// Builds the response matrix for later use
 	Treg := T - p // Usable rows
 
 	// Response matrix Yreg: rows are y_p, y_{p+1}, ..., y_{T-1}
-	Yreg := mat.NewDense(Treg, K, nil)
+	Yreg = mat.NewDense(Treg, K, nil)
 	for t := 0; t < Treg; t++ {
 		for k := 0; k < K; k++ {
 			Yreg.Set(t, k, ts.Y.At(t+p, k))
@@ -361,7 +520,6 @@ func (e *OLSEstimator) Estimate(ts *TimeSeries, spec ModelSpec, opts EstimationO
 	hasConst := spec.Deterministic == DetConst || spec.Deterministic == DetConstTrend
 	hasTrend := spec.Deterministic == DetTrend || spec.Deterministic == DetConstTrend
 
-	detCols := 0
 	if hasConst {
 		detCols++
 	}
@@ -370,12 +528,11 @@ func (e *OLSEstimator) Estimate(ts *TimeSeries, spec ModelSpec, opts EstimationO
 	}
 
 	lagCols := p * K
-	m := detCols + lagCols // total regressors
+	m := detCols + lagCols + exogCols // total regressors
 
-	X := mat.NewDense(Treg, m, nil)
+	X = mat.NewDense(Treg, m, nil)
 
 	// Fill X row-by-row
-
 	for t := 0; t < Treg; t++ {
 		col := 0
 		// time index
@@ -398,10 +555,25 @@ func (e *OLSEstimator) Estimate(ts *TimeSeries, spec ModelSpec, opts EstimationO
 				col++
 			}
 		}
+
+		// Contemporaneous exogenous regressors for y_{t+p}
+		for e := 0; e < exogCols; e++ {
+			X.Set(t, col, ts.X.At(t+p, e))
+			col++
+		}
 	}
 
-	// B = (X'X)^(-1) X'Y
-	// Calculates closed form
+	return X, Yreg, detCols, exogCols, nil
+}
+
+// olsFit solves X B ≈ Yreg for B (m x K) via the normal equations, falling
+// back to an SVD-based minimum-norm pseudoinverse solve when X'X is singular
+// or badly conditioned. Shared by OLSEstimator.Estimate and SelectLagOrder so
+// the fallback logic only lives in one place.
+func olsFit(X, Yreg *mat.Dense) (*mat.Dense, error) {
+	_, m := X.Dims()
+	_, K := Yreg.Dims()
+
 	var B mat.Dense
 
 	// First try: normal equations B = (X'X)^(-1) X'Y
@@ -442,6 +614,31 @@ func (e *OLSEstimator) Estimate(ts *TimeSeries, spec ModelSpec, opts EstimationO
 		}
 	}
 
+	return &B, nil
+}
+
+// --- OLS IMPLEMENTATION ---
+func (e *OLSEstimator) Estimate(ts *TimeSeries, spec ModelSpec, opts EstimationOptions) (*ReducedFormVAR, error) {
+	X, Yreg, detCols, exogCols, err := buildRegressors(ts, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	_, K := ts.Y.Dims()
+	p := spec.Lags
+	Treg, m := X.Dims()
+
+	var Bptr *mat.Dense
+	if opts.Restrictions != nil {
+		Bptr, err = restrictedOLSFit(X, Yreg, opts.Restrictions, detCols, p, K)
+	} else {
+		Bptr, err = olsFit(X, Yreg)
+	}
+	if err != nil {
+		return nil, err
+	}
+	B := *Bptr
+
 	// Split B into C (deterministic) and A_j's
 	var C *mat.Dense
 	if detCols > 0 {
@@ -466,6 +663,18 @@ func (e *OLSEstimator) Estimate(ts *TimeSeries, spec ModelSpec, opts EstimationO
 		A[j] = Aj
 	}
 
+	// Split off the exogenous-coefficient block, if any.
+	var Bexog *mat.Dense
+	if exogCols > 0 {
+		Bexog = mat.NewDense(K, exogCols, nil)
+		rowOffset := detCols + p*K
+		for k := 0; k < K; k++ {
+			for e := 0; e < exogCols; e++ {
+				Bexog.Set(k, e, B.At(rowOffset+e, k))
+			}
+		}
+	}
+
 	// Residual covariance SigmaU
 	var Yhat mat.Dense
 	Yhat.Mul(X, &B)
@@ -489,11 +698,21 @@ func (e *OLSEstimator) Estimate(ts *TimeSeries, spec ModelSpec, opts EstimationO
 	}
 	SigmaU := mat.NewSymDense(K, sigmaData)
 
+	initialLags := mat.NewDense(p, K, nil)
+	for i := 0; i < p; i++ {
+		for k := 0; k < K; k++ {
+			initialLags.Set(i, k, ts.Y.At(i, k))
+		}
+	}
+
 	rf := &ReducedFormVAR{
-		Model:  spec,
-		A:      A,
-		C:      C,
-		SigmaU: SigmaU,
+		Model:            spec,
+		A:                A,
+		C:                C,
+		B:                Bexog,
+		SigmaU:           SigmaU,
+		residualsCache:   mat.DenseCopyOf(&U),
+		initialLagsCache: initialLags,
 	}
 
 	return rf, nil
@@ -538,9 +757,25 @@ func (rf *ReducedFormVAR) GrangerCausality(ts *TimeSeries, causeIdx, effectIdx i
 		detCols++
 	}
 
+	// Exogenous controls (e.g. temperature/humidity) belong in both the
+	// restricted and unrestricted regressions: they're controls, not part of
+	// the lag structure being tested, so leaving them out of either design
+	// would bias both RSS's the same direction but through different paths.
+	exogCols := 0
+	if rf.Model.HasExogenous {
+		if ts.X == nil {
+			return nil, fmt.Errorf("model was fit with HasExogenous but TimeSeries.X is nil")
+		}
+		Tx, mx := ts.X.Dims()
+		if Tx != T {
+			return nil, fmt.Errorf("TimeSeries.X has %d rows, want %d to match TimeSeries.Y", Tx, T)
+		}
+		exogCols = mx
+	}
+
 	// Build UNRESTRICTED model: includes all lagged variables
 	lagCols := p * K
-	mUnrestricted := detCols + lagCols
+	mUnrestricted := detCols + lagCols + exogCols
 	XUnrestricted := mat.NewDense(Treg, mUnrestricted, nil)
 
 	for t := 0; t < Treg; t++ {
@@ -563,6 +798,11 @@ func (rf *ReducedFormVAR) GrangerCausality(ts *TimeSeries, causeIdx, effectIdx i
 				col++
 			}
 		}
+
+		for e := 0; e < exogCols; e++ {
+			XUnrestricted.Set(t, col, ts.X.At(t+p, e))
+			col++
+		}
 	}
 
 	// Fit unrestricted model
@@ -582,7 +822,7 @@ func (rf *ReducedFormVAR) GrangerCausality(ts *TimeSeries, causeIdx, effectIdx i
 	rssUnrestricted := mat.Dot(&residUnrestricted, &residUnrestricted)
 
 	// Build RESTRICTED model: excludes lags of the cause variable
-	mRestricted := detCols + p*(K-1) // exclude p lags of cause variable
+	mRestricted := detCols + p*(K-1) + exogCols // exclude p lags of cause variable
 	XRestricted := mat.NewDense(Treg, mRestricted, nil)
 
 	for t := 0; t < Treg; t++ {
@@ -608,6 +848,11 @@ func (rf *ReducedFormVAR) GrangerCausality(ts *TimeSeries, causeIdx, effectIdx i
 				}
 			}
 		}
+
+		for e := 0; e < exogCols; e++ {
+			XRestricted.Set(t, col, ts.X.At(t+p, e))
+			col++
+		}
 	}
 
 	// Fit restricted model
@@ -706,8 +951,13 @@ func (rf *ReducedFormVAR) GrangerCausalityMatrix(ts *TimeSeries) ([][]*GrangerCa
 }
 
 // This function takes in the created Granger Matrix and outputs it to a CSV file with
-// the columns: CauseVar, EffectVar, FStatistic, PValue, Lags, Significant
-func (rf *ReducedFormVAR) OutputGrangerMatrixToCSV(path string, gcMatrix [][]*GrangerCausalityResult, varNames []string) error {
+// the columns: CauseVar, EffectVar, FStatistic, PValue, Lags, Significant.
+// dates is optional: when nil, the output is exactly as above; when non-nil
+// (typically ts.Dates from a series loaded via LoadCSVToTimeSeriesDated),
+// two trailing columns TestPeriodStart/TestPeriodEnd give the calendar date
+// range the test's regressions were fit over (row Lags..N-1 of dates), since
+// each row tests a variable pair rather than a single time point.
+func (rf *ReducedFormVAR) OutputGrangerMatrixToCSV(path string, gcMatrix [][]*GrangerCausalityResult, varNames []string, dates *DateIndex) error {
 	file, err := os.Create(path)
 
 	if err != nil {
@@ -721,6 +971,9 @@ func (rf *ReducedFormVAR) OutputGrangerMatrixToCSV(path string, gcMatrix [][]*Gr
 
 	// Write header
 	header := []string{"CauseVar", "EffectVar", "FStatistic", "PValue", "Lags", "Significant"}
+	if dates != nil {
+		header = append(header, "TestPeriodStart", "TestPeriodEnd")
+	}
 	if err := writer.Write(header); err != nil {
 		return err
 	}
@@ -745,6 +998,12 @@ func (rf *ReducedFormVAR) OutputGrangerMatrixToCSV(path string, gcMatrix [][]*Gr
 				fmt.Sprintf("%d", result.Lags),
 				fmt.Sprintf("%t", result.Significant),
 			}
+			if dates != nil {
+				record = append(record,
+					dates.At(result.Lags).Format("2006-01-02"),
+					dates.At(dates.N-1).Format("2006-01-02"),
+				)
+			}
 			if err := writer.Write(record); err != nil {
 				return err
 			}
@@ -43,7 +43,7 @@ func TestForecast_SimpleVAR1_NoDeterministic(t *testing.T) {
 	yHist := mat.NewDense(len(histData), 1, histData)
 
 	steps := 3
-	fcst, err := rf.Forecast(yHist, steps)
+	fcst, err := rf.Forecast(yHist, steps, nil)
 	if err != nil {
 		t.Fatalf("Forecast returned error: %v", err)
 	}
@@ -88,7 +88,7 @@ func TestForecast_Var1_ConstantOnly(t *testing.T) {
 	yHist := mat.NewDense(len(histData), 1, histData)
 
 	steps := 4
-	fcst, err := rf.Forecast(yHist, steps)
+	fcst, err := rf.Forecast(yHist, steps, nil)
 	if err != nil {
 		t.Fatalf("Forecast returned error: %v", err)
 	}
@@ -170,7 +170,6 @@ func TestEstimate_SimpleVAR1_NoDeterministic(t *testing.T) {
 
 	ts := &TimeSeries{
 		Y:        Y,
-		Time:     nil,
 		VarNames: []string{"y"},
 	}
 
@@ -212,7 +211,6 @@ func TestEstimate_PseudoinverseFallback(t *testing.T) {
 
 	ts := &TimeSeries{
 		Y:        Y,
-		Time:     nil,
 		VarNames: []string{"y"},
 	}
 
@@ -6,6 +6,7 @@ import (
 	"io"
 	"os"
 	"strconv"
+	"time"
 
 	"gonum.org/v1/gonum/mat"
 )
@@ -18,7 +19,7 @@ import (
 //
 // Returns TimeSeries with:
 //   - Y: T x K matrix (rows: time points, cols: variables)
-//   - Time: []float64 of length T
+//   - Dates: left nil, since there is no date column to index from
 //   - VarNames: []string of length K
 func LoadCSVToTimeSeries(path string) (*TimeSeries, error) {
 	// 1. Open file
@@ -43,9 +44,8 @@ func LoadCSVToTimeSeries(path string) (*TimeSeries, error) {
 	K := len(header) // number of variables
 
 	var (
-		data  []float64 // flat data for mat.Dense
-		times []float64 // time index
-		row   int       // row counter
+		data []float64 // flat data for mat.Dense
+		row  int       // row counter
 	)
 
 	// 4. Read each data row
@@ -81,8 +81,6 @@ func LoadCSVToTimeSeries(path string) (*TimeSeries, error) {
 			data = append(data, v)
 		}
 
-		// Here we just use a simple time index: 0,1,2,...
-		times = append(times, float64(row))
 		row++
 	}
 
@@ -95,16 +93,130 @@ func LoadCSVToTimeSeries(path string) (*TimeSeries, error) {
 	// 5. Build mat.Dense
 	Y := mat.NewDense(T, K, data)
 
-	// 6. Build TimeSeries
+	// 6. Build TimeSeries. No date column, so Dates is left nil.
 	ts := &TimeSeries{
 		Y:        Y,
-		Time:     times,
 		VarNames: header,
 	}
 
 	return ts, nil
 }
 
+// LoadCSVToTimeSeriesDated is LoadCSVToTimeSeries for files that carry an
+// explicit date column:
+//
+//   - dateCol is the header name of the date column.
+//   - layout is a standard Go reference-time layout (e.g. "2006-01-02"), or
+//     the special value "ISOWeek" for strings like "2024-W03".
+//   - freq is one of "Daily", "Weekly", "Monthly", "Quarterly", "Yearly", or
+//     "" to infer the frequency from the spacing of the first two rows.
+//
+// Every remaining column must be numeric. Row spacing is validated against
+// freq (or the inferred frequency) and an error is returned if a gap doesn't
+// match, since that almost always means a missing row rather than a
+// legitimately irregular series.
+func LoadCSVToTimeSeriesDated(path, dateCol, layout, freq string) (*TimeSeries, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.TrimLeadingSpace = true
+
+	header, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read header: %w", err)
+	}
+	if len(header) == 0 {
+		return nil, fmt.Errorf("empty header in %s", path)
+	}
+
+	dateIdx := -1
+	for i, name := range header {
+		if name == dateCol {
+			dateIdx = i
+			break
+		}
+	}
+	if dateIdx == -1 {
+		return nil, fmt.Errorf("date column %q not found in header of %s", dateCol, path)
+	}
+
+	varNames := make([]string, 0, len(header)-1)
+	for i, name := range header {
+		if i != dateIdx {
+			varNames = append(varNames, name)
+		}
+	}
+	K := len(varNames)
+
+	var (
+		data  []float64
+		dates []time.Time
+		row   int
+	)
+
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read row %d: %w", row+2, err)
+		}
+		if len(record) == 1 && record[0] == "" {
+			continue
+		}
+		if len(record) != len(header) {
+			return nil, fmt.Errorf("row %d: expected %d columns, got %d", row+2, len(header), len(record))
+		}
+
+		t, err := parseDateCell(record[dateIdx], layout)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: parse date %q: %w", row+2, record[dateIdx], err)
+		}
+		dates = append(dates, t)
+
+		for j, s := range record {
+			if j == dateIdx {
+				continue
+			}
+			v, err := strconv.ParseFloat(s, 64)
+			if err != nil {
+				return nil, fmt.Errorf("parse float at row %d col %d (%q): %w", row+2, j+1, s, err)
+			}
+			data = append(data, v)
+		}
+		row++
+	}
+
+	if row == 0 {
+		return nil, fmt.Errorf("no data rows in %s", path)
+	}
+	T := row
+
+	f_, err := ParseFrequency(freq)
+	if freq == "" {
+		f_, err = inferFrequency(dates)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("determine frequency: %w", err)
+	}
+	if err := validateFrequency(dates, f_); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	Y := mat.NewDense(T, K, data)
+
+	return &TimeSeries{
+		Y:        Y,
+		VarNames: varNames,
+		Dates:    &DateIndex{Start: dates[0], Freq: f_, N: T},
+	}, nil
+}
+
 // Helper function to print coefficient matrices
 func (rf *ReducedFormVAR) PrintCoefficients() {
 	for i, Ai := range rf.A {
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// buildIRFBandsFixture fits a small, noisy scalar VAR(1) via OLS so the
+// resulting ReducedFormVAR has the residualsCache/initialLagsCache the
+// bootstrap needs.
+func buildIRFBandsFixture(t *testing.T) *ReducedFormVAR {
+	t.Helper()
+	data := []float64{
+		1.0, 0.48, 0.26, 0.11, 0.07, 0.02, -0.03, 0.05, -0.02, 0.04,
+		-0.01, 0.03, 0.00, 0.02, -0.01,
+	}
+	Y := mat.NewDense(len(data), 1, data)
+	ts := &TimeSeries{Y: Y, VarNames: []string{"y"}}
+	spec := ModelSpec{Lags: 1, Deterministic: DetNone}
+
+	rf, err := (&OLSEstimator{}).Estimate(ts, spec, EstimationOptions{})
+	if err != nil {
+		t.Fatalf("Estimate returned error: %v", err)
+	}
+	return rf
+}
+
+func TestIRFBands_BracketsPointEstimate(t *testing.T) {
+	rf := buildIRFBandsFixture(t)
+
+	point, lower, upper, err := rf.IRFBands(5, 0, 200, 0.1, 4)
+	if err != nil {
+		t.Fatalf("IRFBands returned error: %v", err)
+	}
+
+	horizon, K := point.Dims()
+	for h := 0; h < horizon; h++ {
+		for k := 0; k < K; k++ {
+			if lower.At(h, k) > point.At(h, k)+1e-8 || upper.At(h, k) < point.At(h, k)-1e-8 {
+				t.Errorf("h=%d k=%d: point %v not bracketed by [%v, %v]",
+					h, k, point.At(h, k), lower.At(h, k), upper.At(h, k))
+			}
+		}
+	}
+}
+
+func TestIRFBands_ZeroBootReturnsPointOnly(t *testing.T) {
+	rf := buildIRFBandsFixture(t)
+
+	point, lower, upper, err := rf.IRFBands(5, 0, 0, 0.1, 1)
+	if err != nil {
+		t.Fatalf("IRFBands returned error: %v", err)
+	}
+	if lower != nil || upper != nil {
+		t.Errorf("expected nil bands when nBoot <= 0, got lower=%v upper=%v", lower, upper)
+	}
+	if point == nil {
+		t.Errorf("expected a non-nil point estimate")
+	}
+}
+
+func TestIRFBands_RequiresCachedResiduals(t *testing.T) {
+	spec := ModelSpec{Lags: 1, Deterministic: DetNone}
+	A1 := mat.NewDense(1, 1, []float64{0.5})
+	rf := &ReducedFormVAR{Model: spec, A: []*mat.Dense{A1}}
+
+	_, _, _, err := rf.IRFBands(5, 0, 50, 0.1, 1)
+	if err == nil {
+		t.Fatalf("expected an error when residualsCache/initialLagsCache are missing")
+	}
+}
+
+func TestIRFBands_RejectsBadAlpha(t *testing.T) {
+	rf := buildIRFBandsFixture(t)
+
+	_, _, _, err := rf.IRFBands(5, 0, 50, 1.5, 1)
+	if err == nil {
+		t.Fatalf("expected an error for alpha outside (0, 1)")
+	}
+}
+
+func TestIRFBandsHallPercentileT_LowerNotAboveUpper(t *testing.T) {
+	rf := buildIRFBandsFixture(t)
+
+	point, lower, upper, err := rf.IRFBandsHallPercentileT(5, 0, 200, 0.1, 4)
+	if err != nil {
+		t.Fatalf("IRFBandsHallPercentileT returned error: %v", err)
+	}
+
+	horizon, K := point.Dims()
+	for h := 0; h < horizon; h++ {
+		for k := 0; k < K; k++ {
+			if lower.At(h, k) > upper.At(h, k)+1e-8 {
+				t.Errorf("h=%d k=%d: lower %v > upper %v", h, k, lower.At(h, k), upper.At(h, k))
+			}
+		}
+	}
+}
+
+func TestIRFBandsHallPercentileT_RequiresEnoughReplications(t *testing.T) {
+	rf := buildIRFBandsFixture(t)
+
+	_, _, _, err := rf.IRFBandsHallPercentileT(5, 0, 1, 0.1, 1)
+	if err == nil {
+		t.Fatalf("expected an error when nBoot <= 1")
+	}
+}
+
+func TestIRFWithBands_BracketsPointEstimate(t *testing.T) {
+	rf := buildIRFBandsFixture(t)
+
+	point, lower, upper, err := rf.IRFWithBands(5, 0, 200, 0.1)
+	if err != nil {
+		t.Fatalf("IRFWithBands returned error: %v", err)
+	}
+
+	horizon, K := point.Dims()
+	for h := 0; h < horizon; h++ {
+		for k := 0; k < K; k++ {
+			if lower.At(h, k) > point.At(h, k)+1e-8 || upper.At(h, k) < point.At(h, k)-1e-8 {
+				t.Errorf("h=%d k=%d: point %v not bracketed by [%v, %v]",
+					h, k, point.At(h, k), lower.At(h, k), upper.At(h, k))
+			}
+		}
+	}
+}
+
+func TestIRFWithBandsOptions_MovingBlockBracketsPointEstimate(t *testing.T) {
+	rf := buildIRFBandsFixture(t)
+
+	opts := IRFBootstrapOptions{Kind: BootstrapMovingBlock, BlockLength: 2, Workers: 4}
+	point, lower, upper, err := rf.IRFWithBandsOptions(5, 0, 200, 0.1, opts)
+	if err != nil {
+		t.Fatalf("IRFWithBandsOptions returned error: %v", err)
+	}
+
+	horizon, K := point.Dims()
+	for h := 0; h < horizon; h++ {
+		for k := 0; k < K; k++ {
+			if lower.At(h, k) > point.At(h, k)+1e-8 || upper.At(h, k) < point.At(h, k)-1e-8 {
+				t.Errorf("h=%d k=%d: point %v not bracketed by [%v, %v]",
+					h, k, point.At(h, k), lower.At(h, k), upper.At(h, k))
+			}
+		}
+	}
+}
+
+func TestIRFWithBands_ZeroBootReturnsPointOnly(t *testing.T) {
+	rf := buildIRFBandsFixture(t)
+
+	point, lower, upper, err := rf.IRFWithBands(5, 0, 0, 0.1)
+	if err != nil {
+		t.Fatalf("IRFWithBands returned error: %v", err)
+	}
+	if lower != nil || upper != nil {
+		t.Errorf("expected nil bands when nBoot <= 0, got lower=%v upper=%v", lower, upper)
+	}
+	if point == nil {
+		t.Errorf("expected a non-nil point estimate")
+	}
+}
+
+func TestOutputIRFWithBandsToCSV_WritesInterleavedRows(t *testing.T) {
+	rf := buildIRFBandsFixture(t)
+
+	point, lower, upper, err := rf.IRFWithBands(3, 0, 50, 0.1)
+	if err != nil {
+		t.Fatalf("IRFWithBands returned error: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "irf_bands.csv")
+	if err := OutputIRFWithBandsToCSV(path, point, lower, upper, []string{"y"}); err != nil {
+		t.Fatalf("OutputIRFWithBandsToCSV returned error: %v", err)
+	}
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read output CSV: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(contents)), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("expected header + 3 horizon rows, got %d lines", len(lines))
+	}
+	if lines[0] != "Horizon,Variable,Lower,Point,Upper" {
+		t.Errorf("unexpected header: %q", lines[0])
+	}
+}
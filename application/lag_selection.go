@@ -0,0 +1,215 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// LagInfo holds the information-criterion scores for one candidate lag order.
+type LagInfo struct {
+	AIC float64
+	BIC float64
+	HQ  float64
+	FPE float64
+}
+
+// SelectLagOrder fits the OLS VAR for p = 1..maxLags on a common trimmed
+// sample (so scores are comparable across lag orders) and returns the p
+// minimizing the requested criterion ("AIC", "BIC", "HQ", or "FPE", case
+// insensitive) along with the full score table.
+//
+// T_eff = rows(ts.Y) - maxLags is held fixed across every p so every
+// candidate model is fit and compared on the same observations, as is
+// standard practice for lag-order selection.
+func SelectLagOrder(ts *TimeSeries, maxLags int, det Deterministic, criterion string) (int, map[int]LagInfo, error) {
+	scoreFn, err := lagCriterionFunc(criterion)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	scores, err := computeLagScores(ts, maxLags, det)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	best := 0
+	bestScore := math.Inf(1)
+	for p := 1; p <= maxLags; p++ {
+		if s := scoreFn(scores[p]); s < bestScore {
+			bestScore = s
+			best = p
+		}
+	}
+	return best, scores, nil
+}
+
+// LagSelectionResult is the per-criterion report produced by
+// SelectLagOrderReport: the full score table plus the lag order each
+// criterion would pick on its own (they need not agree).
+type LagSelectionResult struct {
+	Scores  map[int]LagInfo
+	BestAIC int
+	BestBIC int
+	BestHQ  int
+	BestFPE int
+}
+
+// SelectLagOrderReport fits the OLS VAR for p = 1..maxLags, the same way
+// SelectLagOrder does, but reports the argmin lag order under AIC, BIC, and
+// HQ (and FPE, for consistency with the existing score table) side by side
+// instead of requiring the caller to pick one criterion up front.
+func SelectLagOrderReport(ts *TimeSeries, maxLags int, det Deterministic) (*LagSelectionResult, error) {
+	scores, err := computeLagScores(ts, maxLags, det)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &LagSelectionResult{Scores: scores}
+	result.BestAIC = argminLagOrder(scores, maxLags, func(i LagInfo) float64 { return i.AIC })
+	result.BestBIC = argminLagOrder(scores, maxLags, func(i LagInfo) float64 { return i.BIC })
+	result.BestHQ = argminLagOrder(scores, maxLags, func(i LagInfo) float64 { return i.HQ })
+	result.BestFPE = argminLagOrder(scores, maxLags, func(i LagInfo) float64 { return i.FPE })
+	return result, nil
+}
+
+func argminLagOrder(scores map[int]LagInfo, maxLags int, score func(LagInfo) float64) int {
+	best := 0
+	bestScore := math.Inf(1)
+	for p := 1; p <= maxLags; p++ {
+		if s := score(scores[p]); s < bestScore {
+			bestScore = s
+			best = p
+		}
+	}
+	return best
+}
+
+// computeLagScores fits the OLS VAR for p = 1..maxLags on a common trimmed
+// sample (so scores are comparable across lag orders) and returns the AIC,
+// BIC, HQ, and FPE scores for each, using the ML (not df-adjusted) residual
+// covariance Sigma_u = U'U/T.
+//
+// T_eff = rows(ts.Y) - maxLags is held fixed across every p so every
+// candidate model is fit and compared on the same observations, as is
+// standard practice for lag-order selection.
+func computeLagScores(ts *TimeSeries, maxLags int, det Deterministic) (map[int]LagInfo, error) {
+	if ts == nil || ts.Y == nil {
+		return nil, fmt.Errorf("time series data not provided")
+	}
+	if maxLags <= 0 {
+		return nil, fmt.Errorf("maxLags must be > 0")
+	}
+
+	T, K := ts.Y.Dims()
+	if T <= maxLags {
+		return nil, fmt.Errorf("need at least maxLags+1 observations: maxLags = %d, T = %d", maxLags, T)
+	}
+
+	Treg := T - maxLags
+
+	hasConst := det == DetConst || det == DetConstTrend
+	hasTrend := det == DetTrend || det == DetConstTrend
+	detCols := 0
+	if hasConst {
+		detCols++
+	}
+	if hasTrend {
+		detCols++
+	}
+
+	// Response matrix is identical for every p: rows maxLags..T-1.
+	Yreg := mat.NewDense(Treg, K, nil)
+	for t := 0; t < Treg; t++ {
+		for k := 0; k < K; k++ {
+			Yreg.Set(t, k, ts.Y.At(maxLags+t, k))
+		}
+	}
+
+	scores := make(map[int]LagInfo, maxLags)
+
+	for p := 1; p <= maxLags; p++ {
+		m := detCols + p*K
+		X := mat.NewDense(Treg, m, nil)
+
+		for t := 0; t < Treg; t++ {
+			col := 0
+			timeIndex := float64(maxLags + t + 1)
+
+			if hasConst {
+				X.Set(t, col, 1.0)
+				col++
+			}
+			if hasTrend {
+				X.Set(t, col, timeIndex)
+				col++
+			}
+
+			for j := 1; j <= p; j++ {
+				srcRow := maxLags + t - j
+				for k := 0; k < K; k++ {
+					X.Set(t, col, ts.Y.At(srcRow, k))
+					col++
+				}
+			}
+		}
+
+		B, err := olsFit(X, Yreg)
+		if err != nil {
+			return nil, fmt.Errorf("lag order %d: %v", p, err)
+		}
+
+		var Yhat mat.Dense
+		Yhat.Mul(X, B)
+		var U mat.Dense
+		U.Sub(Yreg, &Yhat)
+		var utu mat.Dense
+		utu.Mul(U.T(), &U)
+
+		sigmaData := make([]float64, K*K)
+		for i := 0; i < K; i++ {
+			for j := 0; j < K; j++ {
+				sigmaData[i*K+j] = utu.At(i, j) / float64(Treg)
+			}
+		}
+		SigmaU := mat.NewSymDense(K, sigmaData)
+
+		var chol mat.Cholesky
+		if !chol.Factorize(SigmaU) {
+			return nil, fmt.Errorf("lag order %d: residual covariance is not positive definite", p)
+		}
+		logDet := chol.LogDet()
+
+		Tf := float64(Treg)
+		Kf := float64(K)
+		pf := float64(p)
+		df := float64(detCols)
+
+		info := LagInfo{
+			AIC: logDet + 2*pf*Kf*Kf/Tf,
+			BIC: logDet + math.Log(Tf)*pf*Kf*Kf/Tf,
+			HQ:  logDet + 2*math.Log(math.Log(Tf))*pf*Kf*Kf/Tf,
+			FPE: math.Pow((Tf+Kf*pf+df)/(Tf-Kf*pf-df), Kf) * math.Exp(logDet),
+		}
+		scores[p] = info
+	}
+
+	return scores, nil
+}
+
+func lagCriterionFunc(criterion string) (func(LagInfo) float64, error) {
+	switch strings.ToUpper(criterion) {
+	case "AIC":
+		return func(i LagInfo) float64 { return i.AIC }, nil
+	case "BIC", "SC":
+		return func(i LagInfo) float64 { return i.BIC }, nil
+	case "HQ":
+		return func(i LagInfo) float64 { return i.HQ }, nil
+	case "FPE":
+		return func(i LagInfo) float64 { return i.FPE }, nil
+	default:
+		return nil, fmt.Errorf("unknown lag selection criterion %q (want AIC, BIC, HQ, or FPE)", criterion)
+	}
+}
@@ -1,17 +1,28 @@
 package main
 
 import (
+	"flag"
 	"fmt"
-	"os"
+	"strconv"
+	"strings"
 )
 
 func main() {
-	// expect 2 argument: country name, influenza type
-	if len(os.Args) < 3 {
-		fmt.Println("Usage: go run main.go <country_name> <influenza_type>")
+	autoLags := flag.Int("auto-lags", 0, "if > 0, pick the lag order in [1, N] by -lag-criterion (AIC by default) instead of the hard-coded default")
+	lagCriterion := flag.String("lag-criterion", "AIC", "criterion used with -auto-lags: AIC, BIC, HQ, or FPE")
+	svarScheme := flag.String("svar-scheme", "none", "structural identification scheme for StructuralIRF, beyond the default Cholesky ordering IRF uses: none, shortrun, proxy, or sign")
+	svarShock := flag.Int("svar-shock", 0, "shock index used with -svar-scheme for StructuralIRF/sign-pattern targeting")
+	svarProxyCSV := flag.String("svar-proxy-csv", "", "required with -svar-scheme=proxy: path to a single-column CSV of the external instrument series")
+	svarSignPattern := flag.String("svar-sign-pattern", "", "required with -svar-scheme=sign: semicolon-separated rows of comma-separated {+,-,0} entries, e.g. \"+,0;0,+\"")
+	flag.Parse()
+
+	// expect 2 positional args: country name, influenza type
+	args := flag.Args()
+	if len(args) < 2 {
+		fmt.Println("Usage: go run main.go [-auto-lags N] <country_name> <influenza_type>")
 		return
 	}
-	country := os.Args[1]
+	country := args[0]
 	fmt.Println("Running VAR analysis for country:", country)
 	// Determine filename based on country
 	var filename string
@@ -24,7 +35,7 @@ func main() {
 		panic("Unsupported country: " + country + ". Options: Singapore, Qatar")
 	}
 
-	influenzaType := os.Args[2]
+	influenzaType := args[1]
 	var influenzaVarIndex string
 	switch influenzaType {
 	case "A":
@@ -43,8 +54,19 @@ func main() {
 		ts.Y.RawMatrix().Cols, "variables:", ts.VarNames)
 
 	// 2. Set up VAR spec
+	lags := 6
+	if *autoLags > 0 {
+		selected, scores, err := SelectLagOrder(ts, *autoLags, DetConst, *lagCriterion)
+		if err != nil {
+			panic(err)
+		}
+		fmt.Printf("Lag order selection (%s) over 1..%d: %+v\n", *lagCriterion, *autoLags, scores)
+		fmt.Println("Selected lag order:", selected)
+		lags = selected
+	}
+
 	spec := ModelSpec{
-		Lags:          6,
+		Lags:          lags,
 		Deterministic: DetConst, // or DetConstTrend, etc.
 		HasExogenous:  false,
 	}
@@ -58,7 +80,7 @@ func main() {
 	rf.PrintCoefficients()
 
 	// 4. Forecast 10 steps ahead
-	fcst, err := rf.Forecast(ts.Y, 10)
+	fcst, err := rf.Forecast(ts.Y, 10, nil)
 	if err != nil {
 		panic(err)
 	}
@@ -72,11 +94,34 @@ func main() {
 	}
 	PrintIRF(irfMat, ts.VarNames, 2)
 
+	// 5b. Optionally identify a StructuralVAR and print its structural IRF,
+	// using whichever scheme -svar-scheme selects in place of the recursive
+	// Cholesky ordering used above.
+	if *svarScheme != "none" {
+		treg, _ := rf.residualsCache.Dims()
+		opts, err := buildStructuralOptions(*svarScheme, *svarProxyCSV, *svarSignPattern, *svarShock, len(ts.VarNames), treg)
+		if err != nil {
+			panic(err)
+		}
+
+		sv, err := rf.Identify(opts)
+		if err != nil {
+			panic(err)
+		}
+
+		structIRF, err := sv.StructuralIRF(12, *svarShock)
+		if err != nil {
+			panic(err)
+		}
+		fmt.Printf("Structural IRF (scheme: %s, shock: %d):\n", *svarScheme, *svarShock)
+		PrintIRF(structIRF, ts.VarNames, *svarShock)
+	}
+
 	// 6. Prints Summary
 	rf.Summary(ts)
 
 	// 7. Ouptput residuals to CSV
-	err = rf.OutputForecastsToCSV("../Files/Output/forecast_results.csv", fcst, ts.VarNames)
+	err = rf.OutputForecastsToCSV("../Files/Output/forecast_results.csv", fcst, ts.VarNames, ts.Dates)
 	if err != nil {
 		panic(err)
 	}
@@ -91,7 +136,7 @@ func main() {
 	PrintGrangerCausality(grangerResults, ts.VarNames)
 
 	// 9. Output Granger results to CSV
-	err = rf.OutputGrangerMatrixToCSV("../Files/Output/granger_results.csv", grangerResults, ts.VarNames)
+	err = rf.OutputGrangerMatrixToCSV("../Files/Output/granger_results.csv", grangerResults, ts.VarNames, ts.Dates)
 	if err != nil {
 		panic(err)
 	}
@@ -110,10 +155,87 @@ func main() {
 	}
 
 	// 11. Output shocking results to CSV
-	err = rf.OutputIRFAnalysisToCSV("../Files/Output/irf_results.csv", shockResults, ts.VarNames)
+	err = rf.OutputIRFAnalysisToCSV("../Files/Output/irf_results.csv", shockResults, ts.VarNames, ts.Dates)
 	if err != nil {
 		panic(err)
 	}
 
 	fmt.Println("IRF analysis results written to ../Files/Output/irf_results.csv")
 }
+
+// buildStructuralOptions turns the -svar-* flags into a StructuralOptions for
+// the requested scheme (shortrun, proxy, or sign). treg is the fitted
+// reduced-form residual count (rf.residualsCache's row count), which is what
+// identifyProxy's Proxy series must line up with, not the raw CSV length.
+func buildStructuralOptions(scheme, proxyCSV, signPattern string, shockIndex, K, treg int) (StructuralOptions, error) {
+	switch scheme {
+	case "shortrun":
+		return StructuralOptions{Scheme: SchemeShortRun, ZeroMask: RecursiveZeroMask(K)}, nil
+	case "proxy":
+		if proxyCSV == "" {
+			return StructuralOptions{}, fmt.Errorf("-svar-scheme=proxy requires -svar-proxy-csv")
+		}
+		proxyTS, err := LoadCSVToTimeSeries(proxyCSV)
+		if err != nil {
+			return StructuralOptions{}, fmt.Errorf("loading -svar-proxy-csv: %w", err)
+		}
+		T, _ := proxyTS.Y.Dims()
+		if T < treg {
+			return StructuralOptions{}, fmt.Errorf("-svar-proxy-csv has %d rows, need at least %d to cover the fitted residual sample", T, treg)
+		}
+		// The fitted residuals are the model's last treg observations (the
+		// first Lags rows have no residual), so the proxy must be trimmed the
+		// same way to stay aligned row-for-row.
+		proxy := make([]float64, treg)
+		for i := 0; i < treg; i++ {
+			proxy[i] = proxyTS.Y.At(T-treg+i, 0)
+		}
+		return StructuralOptions{Scheme: SchemeProxy, Proxy: proxy, ProxyShockIndex: shockIndex}, nil
+	case "sign":
+		if signPattern == "" {
+			return StructuralOptions{}, fmt.Errorf("-svar-scheme=sign requires -svar-sign-pattern")
+		}
+		pattern, err := parseSignPattern(signPattern, K)
+		if err != nil {
+			return StructuralOptions{}, err
+		}
+		return StructuralOptions{Scheme: SchemeSignRestrictions, SignPattern: pattern, TargetShock: shockIndex}, nil
+	default:
+		return StructuralOptions{}, fmt.Errorf("unknown -svar-scheme %q: want none, shortrun, proxy, or sign", scheme)
+	}
+}
+
+// parseSignPattern parses a -svar-sign-pattern string like "+,0;0,+" into a
+// KxK grid of {-1, 0, 1}, matching StructuralOptions.SignPattern.
+func parseSignPattern(s string, K int) ([][]int, error) {
+	rows := strings.Split(s, ";")
+	if len(rows) != K {
+		return nil, fmt.Errorf("-svar-sign-pattern must have %d rows separated by ';', got %d", K, len(rows))
+	}
+
+	pattern := make([][]int, K)
+	for i, row := range rows {
+		cells := strings.Split(row, ",")
+		if len(cells) != K {
+			return nil, fmt.Errorf("-svar-sign-pattern row %d must have %d entries separated by ',', got %d", i, K, len(cells))
+		}
+		pattern[i] = make([]int, K)
+		for j, cell := range cells {
+			switch strings.TrimSpace(cell) {
+			case "+":
+				pattern[i][j] = 1
+			case "-":
+				pattern[i][j] = -1
+			case "0":
+				pattern[i][j] = 0
+			default:
+				v, err := strconv.Atoi(strings.TrimSpace(cell))
+				if err != nil || (v != -1 && v != 0 && v != 1) {
+					return nil, fmt.Errorf("-svar-sign-pattern entry %q must be one of +, -, 0", cell)
+				}
+				pattern[i][j] = v
+			}
+		}
+	}
+	return pattern, nil
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestBuildStructuralOptions_ShortRun(t *testing.T) {
+	opts, err := buildStructuralOptions("shortrun", "", "", 0, 2, 5)
+	if err != nil {
+		t.Fatalf("buildStructuralOptions returned error: %v", err)
+	}
+	if opts.Scheme != SchemeShortRun {
+		t.Errorf("Scheme = %v, want SchemeShortRun", opts.Scheme)
+	}
+	if len(opts.ZeroMask) != 2 {
+		t.Errorf("ZeroMask has %d rows, want 2", len(opts.ZeroMask))
+	}
+}
+
+func TestBuildStructuralOptions_Proxy_TrimsToFittedResidualCount(t *testing.T) {
+	// 5 raw rows, but only the last treg=3 line up with the fitted residuals
+	// (the model's first Lags rows have no residual).
+	path := writeCSV(t, "z\n10.0\n20.0\n30.0\n40.0\n50.0\n")
+
+	opts, err := buildStructuralOptions("proxy", path, "", 1, 2, 3)
+	if err != nil {
+		t.Fatalf("buildStructuralOptions returned error: %v", err)
+	}
+	if opts.Scheme != SchemeProxy {
+		t.Errorf("Scheme = %v, want SchemeProxy", opts.Scheme)
+	}
+	want := []float64{30.0, 40.0, 50.0}
+	if len(opts.Proxy) != len(want) {
+		t.Fatalf("Proxy has %d entries, want %d", len(opts.Proxy), len(want))
+	}
+	for i, v := range want {
+		if !almostEqual(opts.Proxy[i], v, 1e-8) {
+			t.Errorf("Proxy[%d] = %v, want %v", i, opts.Proxy[i], v)
+		}
+	}
+	if opts.ProxyShockIndex != 1 {
+		t.Errorf("ProxyShockIndex = %d, want 1", opts.ProxyShockIndex)
+	}
+}
+
+func TestBuildStructuralOptions_Proxy_RejectsCSVShorterThanResidualSample(t *testing.T) {
+	path := writeCSV(t, "z\n10.0\n20.0\n")
+
+	if _, err := buildStructuralOptions("proxy", path, "", 0, 2, 5); err == nil {
+		t.Fatalf("expected an error when the proxy CSV has fewer rows than the fitted residual sample")
+	}
+}
+
+func TestBuildStructuralOptions_Proxy_RequiresCSVFlag(t *testing.T) {
+	if _, err := buildStructuralOptions("proxy", "", "", 0, 2, 5); err == nil {
+		t.Fatalf("expected an error when -svar-proxy-csv is missing")
+	}
+}
+
+func TestBuildStructuralOptions_Sign(t *testing.T) {
+	opts, err := buildStructuralOptions("sign", "", "+,0;0,-", 0, 2, 5)
+	if err != nil {
+		t.Fatalf("buildStructuralOptions returned error: %v", err)
+	}
+	if opts.Scheme != SchemeSignRestrictions {
+		t.Errorf("Scheme = %v, want SchemeSignRestrictions", opts.Scheme)
+	}
+	want := [][]int{{1, 0}, {0, -1}}
+	for i := range want {
+		for j := range want[i] {
+			if opts.SignPattern[i][j] != want[i][j] {
+				t.Errorf("SignPattern[%d][%d] = %d, want %d", i, j, opts.SignPattern[i][j], want[i][j])
+			}
+		}
+	}
+}
+
+func TestBuildStructuralOptions_RejectsUnknownScheme(t *testing.T) {
+	if _, err := buildStructuralOptions("bogus", "", "", 0, 2, 5); err == nil {
+		t.Fatalf("expected an error for an unknown scheme")
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// Restrictions encodes user-specified zero constraints on a VAR's
+// coefficients, consulted by OLSEstimator via EstimationOptions.Restrictions.
+// Restricting a coefficient to zero lets users encode a theoretical prior
+// like "lag 2 of variable A has no effect on variable B".
+type Restrictions struct {
+	// LagMask[j][eq][col] == true restricts A_j[eq][col] to zero. A nil or
+	// short entry (missing lag, or missing equation row within a lag) leaves
+	// that lag/equation unrestricted.
+	LagMask [][][]bool
+	// DetMask[eq][d] == true restricts the deterministic coefficient C[eq][d]
+	// to zero. Nil leaves the deterministic block unrestricted.
+	DetMask [][]bool
+}
+
+// restrictedColumns reports, for equation eq, which columns of the combined
+// regressor matrix X (ordered [deterministic | lag 1..p | exogenous], the
+// same layout buildRegressors produces) are restricted to zero under r.
+func (r *Restrictions) restrictedColumns(eq, detCols, p, K int) map[int]bool {
+	restricted := make(map[int]bool)
+	if r == nil {
+		return restricted
+	}
+	if r.DetMask != nil && eq < len(r.DetMask) {
+		for d, zero := range r.DetMask[eq] {
+			if zero {
+				restricted[d] = true
+			}
+		}
+	}
+	for j := 0; j < p; j++ {
+		if j >= len(r.LagMask) || r.LagMask[j] == nil || eq >= len(r.LagMask[j]) {
+			continue
+		}
+		rowOffset := detCols + j*K
+		for col, zero := range r.LagMask[j][eq] {
+			if zero {
+				restricted[rowOffset+col] = true
+			}
+		}
+	}
+	return restricted
+}
+
+// restrictedOLSFit solves X B ~= Yreg equation by equation: for each response
+// column eq, it drops the columns r marks as restricted-to-zero for that
+// equation, solves the smaller OLS problem via olsFit, and scatters the
+// fitted coefficients back into the full m x K matrix, leaving zeros in the
+// restricted slots.
+func restrictedOLSFit(X, Yreg *mat.Dense, r *Restrictions, detCols, p, K int) (*mat.Dense, error) {
+	Treg, m := X.Dims()
+	B := mat.NewDense(m, K, nil)
+
+	for eq := 0; eq < K; eq++ {
+		restricted := r.restrictedColumns(eq, detCols, p, K)
+
+		active := make([]int, 0, m)
+		for col := 0; col < m; col++ {
+			if !restricted[col] {
+				active = append(active, col)
+			}
+		}
+		if len(active) == 0 {
+			// Every coefficient for this equation is restricted to zero;
+			// B's column for eq stays all-zero.
+			continue
+		}
+
+		Xsub := mat.NewDense(Treg, len(active), nil)
+		for t := 0; t < Treg; t++ {
+			for i, col := range active {
+				Xsub.Set(t, i, X.At(t, col))
+			}
+		}
+
+		Yeq := mat.NewDense(Treg, 1, nil)
+		for t := 0; t < Treg; t++ {
+			Yeq.Set(t, 0, Yreg.At(t, eq))
+		}
+
+		Bsub, err := olsFit(Xsub, Yeq)
+		if err != nil {
+			return nil, fmt.Errorf("restricted OLS for equation %d: %v", eq, err)
+		}
+
+		for i, col := range active {
+			B.Set(col, eq, Bsub.At(i, 0))
+		}
+	}
+
+	return B, nil
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// buildRestrictionsFixture builds a noise-free 2-variable VAR(1) where
+// variable 2 has no dependence on lagged variable 1: y2_t = 0.4 y2_{t-1}.
+// y1_t = 0.5 y1_{t-1} + 0.3 y2_{t-1} has a genuine cross-lag effect.
+func buildRestrictionsFixture() (*TimeSeries, ModelSpec) {
+	const n = 40
+	data := make([]float64, n*2)
+	data[0], data[1] = 1.0, 1.0
+	for t := 1; t < n; t++ {
+		y1Prev, y2Prev := data[(t-1)*2], data[(t-1)*2+1]
+		data[t*2] = 0.5*y1Prev + 0.3*y2Prev
+		data[t*2+1] = 0.4 * y2Prev
+	}
+	Y := mat.NewDense(n, 2, data)
+	ts := &TimeSeries{Y: Y, VarNames: []string{"y1", "y2"}}
+	spec := ModelSpec{Lags: 1, Deterministic: DetNone}
+	return ts, spec
+}
+
+func TestOLSEstimate_RestrictionsZeroOutMaskedCoefficient(t *testing.T) {
+	ts, spec := buildRestrictionsFixture()
+
+	restrictions := &Restrictions{
+		LagMask: [][][]bool{
+			{
+				{false, false}, // eq 0 (y1): unrestricted
+				{true, false},  // eq 1 (y2): lag-1 y1 coefficient forced to zero
+			},
+		},
+	}
+
+	rf, err := (&OLSEstimator{}).Estimate(ts, spec, EstimationOptions{Restrictions: restrictions})
+	if err != nil {
+		t.Fatalf("Estimate returned error: %v", err)
+	}
+
+	if got := rf.A[0].At(1, 0); got != 0 {
+		t.Errorf("A[0].At(1,0) = %v, want exactly 0 (restricted)", got)
+	}
+	if got := rf.A[0].At(1, 1); !almostEqual(got, 0.4, 1e-6) {
+		t.Errorf("A[0].At(1,1) = %v, want ~0.4", got)
+	}
+	if got := rf.A[0].At(0, 1); !almostEqual(got, 0.3, 1e-6) {
+		t.Errorf("unrestricted A[0].At(0,1) = %v, want ~0.3", got)
+	}
+}
+
+func TestOLSEstimate_NilRestrictionsMatchesUnrestrictedFit(t *testing.T) {
+	ts, spec := buildRestrictionsFixture()
+
+	withNil, err := (&OLSEstimator{}).Estimate(ts, spec, EstimationOptions{})
+	if err != nil {
+		t.Fatalf("Estimate returned error: %v", err)
+	}
+	withEmpty, err := (&OLSEstimator{}).Estimate(ts, spec, EstimationOptions{Restrictions: &Restrictions{}})
+	if err != nil {
+		t.Fatalf("Estimate returned error: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 2; j++ {
+			a, b := withNil.A[0].At(i, j), withEmpty.A[0].At(i, j)
+			if !almostEqual(a, b, 1e-8) {
+				t.Errorf("A[0].At(%d,%d): nil opts gave %v, empty Restrictions gave %v", i, j, a, b)
+			}
+		}
+	}
+}
+
+func TestOLSEstimate_FullyRestrictedEquationIsAllZero(t *testing.T) {
+	ts, spec := buildRestrictionsFixture()
+
+	restrictions := &Restrictions{
+		LagMask: [][][]bool{
+			{
+				{false, false},
+				{true, true}, // eq 1 (y2): every coefficient restricted
+			},
+		},
+	}
+
+	rf, err := (&OLSEstimator{}).Estimate(ts, spec, EstimationOptions{Restrictions: restrictions})
+	if err != nil {
+		t.Fatalf("Estimate returned error: %v", err)
+	}
+
+	if got := rf.A[0].At(1, 0); got != 0 {
+		t.Errorf("A[0].At(1,0) = %v, want 0", got)
+	}
+	if got := rf.A[0].At(1, 1); got != 0 {
+		t.Errorf("A[0].At(1,1) = %v, want 0", got)
+	}
+}
@@ -0,0 +1,601 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// IdentificationScheme selects how StructuralVAR resolves the contemporaneous
+// impact matrix B from the reduced-form residual covariance SigmaU. The
+// recursive Cholesky ordering IRF already uses is the implicit default
+// everywhere else in this package; Identify is how callers reach for
+// something else.
+type IdentificationScheme int
+
+const (
+	// SchemeShortRun imposes zero restrictions on an arbitrary pattern of B.
+	SchemeShortRun IdentificationScheme = iota
+	// SchemeProxy identifies one structural shock from an external instrument.
+	SchemeProxy
+	// SchemeSignRestrictions draws random orthogonal rotations of the
+	// Cholesky factor and keeps those matching a sign pattern on impact.
+	SchemeSignRestrictions
+	// SchemeShortRunPattern generalizes SchemeShortRun to restrictions that
+	// fix an entry of B to an arbitrary value, not just zero.
+	SchemeShortRunPattern
+	// SchemeLongRun imposes Blanchard-Quah long-run restrictions: the
+	// cumulative (long-run) impact matrix is required lower-triangular.
+	SchemeLongRun
+)
+
+// StructuralOptions carries the parameters for whichever IdentificationScheme
+// is requested. Only the fields relevant to the chosen scheme are read.
+type StructuralOptions struct {
+	Scheme IdentificationScheme
+
+	// --- SchemeShortRun ---
+	// ZeroMask[i][j] == true means B[i][j] is restricted to zero.
+	// The count of free (false) entries must equal K(K+1)/2 for exact
+	// identification.
+	ZeroMask [][]bool
+
+	// --- SchemeShortRunPattern ---
+	// Pattern[i][j] fixes B[i][j] to that value; math.NaN() marks a free
+	// entry. The count of free entries must equal K(K+1)/2 for exact
+	// identification.
+	Pattern [][]float64
+
+	// --- SchemeProxy ---
+	// Proxy is the external instrument series, aligned one-for-one with the
+	// sample of fitted residuals it overlaps (Proxy[t] <-> residual row t).
+	Proxy []float64
+	// ProxyShockIndex is which structural shock column the proxy identifies.
+	ProxyShockIndex int
+
+	// --- SchemeSignRestrictions ---
+	// SignPattern[i][j] in {-1, 0, 1}: required sign of variable i's impact
+	// response to shock j (0 = unrestricted).
+	SignPattern [][]int
+	// NumDraws is how many random rotations to try (default 1000 if 0).
+	NumDraws int
+	// TargetShock/TargetVariable pick which impact response is used to break
+	// ties among accepted rotations: the accepted draw whose response is
+	// closest to the median across all accepted draws is returned, following
+	// Fry & Pagan's median-target recommendation over pointwise medians.
+	TargetShock, TargetVariable int
+	// Seed seeds the rotation draws; 0 is treated as seed 1, so draws are
+	// always reproducible.
+	Seed int64
+}
+
+// RecursiveZeroMask returns the ZeroMask for the standard recursive
+// (lower-triangular) ordering: B[i][j] restricted to zero for all j > i,
+// same as the Cholesky factor IRF uses by default. Useful as the SchemeShortRun
+// starting point for callers (like the CLI) that want "the usual ordering"
+// without hand-writing a KxK mask.
+func RecursiveZeroMask(K int) [][]bool {
+	mask := make([][]bool, K)
+	for i := 0; i < K; i++ {
+		mask[i] = make([]bool, K)
+		for j := 0; j < K; j++ {
+			mask[i][j] = j > i
+		}
+	}
+	return mask
+}
+
+// StructuralVAR wraps a fitted ReducedFormVAR with an identified structural
+// impact matrix B (SigmaU = B * B').
+type StructuralVAR struct {
+	RF *ReducedFormVAR
+	B  *mat.Dense
+}
+
+// Identify resolves a StructuralVAR from rf's reduced-form residual
+// covariance using the scheme and parameters in opts.
+func (rf *ReducedFormVAR) Identify(opts StructuralOptions) (*StructuralVAR, error) {
+	if rf == nil || rf.SigmaU == nil {
+		return nil, fmt.Errorf("VAR model not estimated")
+	}
+
+	var B *mat.Dense
+	var err error
+
+	switch opts.Scheme {
+	case SchemeShortRun:
+		B, err = identifyShortRun(rf.SigmaU, opts.ZeroMask)
+	case SchemeProxy:
+		B, err = identifyProxy(rf, opts.Proxy, opts.ProxyShockIndex)
+	case SchemeSignRestrictions:
+		B, err = identifySignRestrictions(rf.SigmaU, opts)
+	case SchemeShortRunPattern:
+		B, err = identifyShortRunPattern(rf.SigmaU, opts.Pattern)
+	case SchemeLongRun:
+		B, err = identifyLongRun(rf)
+	default:
+		return nil, fmt.Errorf("unknown identification scheme: %d", opts.Scheme)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &StructuralVAR{RF: rf, B: B}, nil
+}
+
+// StructuralIRF computes the impulse response of all variables to a one-time
+// structural shock in shockIndex, using the identified impact matrix B in
+// place of the Cholesky factor IRF uses.
+func (s *StructuralVAR) StructuralIRF(horizon int, shockIndex int) (*mat.Dense, error) {
+	if s == nil || s.RF == nil || s.B == nil {
+		return nil, fmt.Errorf("structural VAR not identified")
+	}
+	if horizon <= 0 {
+		return nil, fmt.Errorf("horizon must be > 0")
+	}
+	K, _ := s.B.Dims()
+	if shockIndex < 0 || shockIndex >= K {
+		return nil, fmt.Errorf("shockIndex must be between 0 and %d", K-1)
+	}
+
+	impact := make([]float64, K)
+	for i := 0; i < K; i++ {
+		impact[i] = s.B.At(i, shockIndex)
+	}
+
+	Psi := psiMatrices(s.RF.A, horizon)
+	return irfFromImpact(Psi, impact), nil
+}
+
+// FEVD returns, for each horizon h=1..horizon, a K x K matrix whose (i,j)
+// entry is the share of variable i's h-step forecast error variance
+// explained by structural shock j, using the identified B instead of the
+// recursive Cholesky factor.
+func (s *StructuralVAR) FEVD(horizon int) ([]*mat.Dense, error) {
+	if s == nil || s.RF == nil || s.B == nil {
+		return nil, fmt.Errorf("structural VAR not identified")
+	}
+	if horizon <= 0 {
+		return nil, fmt.Errorf("horizon must be > 0")
+	}
+
+	K, _ := s.B.Dims()
+	Psi := psiMatrices(s.RF.A, horizon)
+	return fevdFromImpact(Psi, s.B, K, horizon), nil
+}
+
+// identifyShortRun solves for B such that B*B' = SigmaU subject to B[i][j]==0
+// wherever zeroMask[i][j] is true. The system is exactly identified only when
+// the number of free entries equals K(K+1)/2; Gauss-Newton refines an
+// initial Cholesky-derived guess restricted to the pattern.
+func identifyShortRun(SigmaU *mat.SymDense, zeroMask [][]bool) (*mat.Dense, error) {
+	K := SigmaU.SymmetricDim()
+	if len(zeroMask) != K {
+		return nil, fmt.Errorf("ZeroMask must be %dx%d", K, K)
+	}
+
+	var free []shortRunIdx
+	for i := 0; i < K; i++ {
+		if len(zeroMask[i]) != K {
+			return nil, fmt.Errorf("ZeroMask row %d must have length %d", i, K)
+		}
+		for j := 0; j < K; j++ {
+			if !zeroMask[i][j] {
+				free = append(free, shortRunIdx{i, j})
+			}
+		}
+	}
+
+	nEq := K * (K + 1) / 2
+	if len(free) != nEq {
+		return nil, fmt.Errorf("short-run restrictions not exactly identified: %d free entries, need %d for a %dx%d system", len(free), nEq, K, K)
+	}
+
+	var chol mat.Cholesky
+	if !chol.Factorize(SigmaU) {
+		return nil, fmt.Errorf("SigmaU is not positive definite")
+	}
+	L := mat.NewTriDense(K, mat.Lower, nil)
+	chol.LTo(L)
+
+	B := mat.NewDense(K, K, nil)
+	for i := 0; i < K; i++ {
+		for j := 0; j < K; j++ {
+			if !zeroMask[i][j] {
+				B.Set(i, j, L.At(i, j))
+			}
+		}
+	}
+
+	if err := gaussNewtonRefineB(B, SigmaU, free); err != nil {
+		return nil, fmt.Errorf("short-run identification did not converge: %v", err)
+	}
+	return B, nil
+}
+
+// shortRunIdx names one free (row, col) entry of B that gaussNewtonRefineB is
+// allowed to perturb.
+type shortRunIdx struct{ i, j int }
+
+// gaussNewtonRefineB refines the free entries of B in place via Gauss-Newton
+// so that B*B' matches SigmaU as closely as possible. B's entries not listed
+// in free are treated as fixed and must already hold their required values on
+// entry. Shared by identifyShortRun (free = the off-zero-mask entries) and
+// identifyShortRunPattern (free = the NaN entries of an arbitrary pattern).
+func gaussNewtonRefineB(B *mat.Dense, SigmaU *mat.SymDense, free []shortRunIdx) error {
+	K, _ := B.Dims()
+	nEq := K * (K + 1) / 2
+
+	const maxIter = 100
+	const tol = 1e-20
+	const eps = 1e-6
+
+	var normSq float64
+	converged := false
+
+	for iter := 0; iter < maxIter; iter++ {
+		var BBt mat.Dense
+		BBt.Mul(B, B.T())
+
+		resid := make([]float64, 0, nEq)
+		for i := 0; i < K; i++ {
+			for j := i; j < K; j++ {
+				resid = append(resid, BBt.At(i, j)-SigmaU.At(i, j))
+			}
+		}
+
+		normSq = 0
+		for _, r := range resid {
+			normSq += r * r
+		}
+		if normSq < tol {
+			converged = true
+			break
+		}
+
+		n := len(free)
+		Jac := mat.NewDense(nEq, n, nil)
+		for p, f := range free {
+			orig := B.At(f.i, f.j)
+			B.Set(f.i, f.j, orig+eps)
+			var bumped mat.Dense
+			bumped.Mul(B, B.T())
+			B.Set(f.i, f.j, orig)
+
+			row := 0
+			for i := 0; i < K; i++ {
+				for j := i; j < K; j++ {
+					Jac.Set(row, p, (bumped.At(i, j)-BBt.At(i, j))/eps)
+					row++
+				}
+			}
+		}
+
+		residVec := mat.NewVecDense(nEq, resid)
+		var step mat.VecDense
+		if err := step.SolveVec(Jac, residVec); err != nil {
+			return err
+		}
+		for p, f := range free {
+			B.Set(f.i, f.j, B.At(f.i, f.j)-step.AtVec(p))
+		}
+	}
+
+	if !converged {
+		return fmt.Errorf("gauss-newton refinement did not converge after %d iterations: residual norm^2 %v (want < %v)", maxIter, normSq, tol)
+	}
+	return nil
+}
+
+// identifyShortRunPattern generalizes identifyShortRun to fixing B's entries
+// to arbitrary values, not just zero: pattern[i][j] fixes B[i][j] to that
+// value, and math.NaN() marks a free entry, solved the same way by
+// Gauss-Newton minimization of ||B*B' - SigmaU||_F starting from a
+// Cholesky-derived guess restricted to the pattern.
+func identifyShortRunPattern(SigmaU *mat.SymDense, pattern [][]float64) (*mat.Dense, error) {
+	K := SigmaU.SymmetricDim()
+	if len(pattern) != K {
+		return nil, fmt.Errorf("Pattern must be %dx%d", K, K)
+	}
+
+	var free []shortRunIdx
+	for i := 0; i < K; i++ {
+		if len(pattern[i]) != K {
+			return nil, fmt.Errorf("Pattern row %d must have length %d", i, K)
+		}
+		for j := 0; j < K; j++ {
+			if math.IsNaN(pattern[i][j]) {
+				free = append(free, shortRunIdx{i, j})
+			}
+		}
+	}
+
+	nEq := K * (K + 1) / 2
+	if len(free) != nEq {
+		return nil, fmt.Errorf("short-run pattern not exactly identified: %d free entries, need %d for a %dx%d system", len(free), nEq, K, K)
+	}
+
+	var chol mat.Cholesky
+	if !chol.Factorize(SigmaU) {
+		return nil, fmt.Errorf("SigmaU is not positive definite")
+	}
+	L := mat.NewTriDense(K, mat.Lower, nil)
+	chol.LTo(L)
+
+	B := mat.NewDense(K, K, nil)
+	for i := 0; i < K; i++ {
+		for j := 0; j < K; j++ {
+			if math.IsNaN(pattern[i][j]) {
+				B.Set(i, j, L.At(i, j))
+			} else {
+				B.Set(i, j, pattern[i][j])
+			}
+		}
+	}
+
+	if err := gaussNewtonRefineB(B, SigmaU, free); err != nil {
+		return nil, fmt.Errorf("short-run pattern identification did not converge: %v", err)
+	}
+	return B, nil
+}
+
+// identifyLongRun imposes Blanchard-Quah long-run restrictions: the
+// cumulative long-run multiplier Xi = (I - sum_j A_j)^-1 * B_0 is required
+// lower-triangular. Xi*Xi' = (I - sum A_j)^-1 SigmaU (I - sum A_j)^-T, so its
+// Cholesky factor L is the unique lower-triangular Xi satisfying that (up to
+// sign normalization), and B_0 = (I - sum A_j) * L.
+func identifyLongRun(rf *ReducedFormVAR) (*mat.Dense, error) {
+	K, _ := rf.A[0].Dims()
+
+	ImSumA := mat.NewDense(K, K, nil)
+	for i := 0; i < K; i++ {
+		ImSumA.Set(i, i, 1.0)
+	}
+	for _, Aj := range rf.A {
+		ImSumA.Sub(ImSumA, Aj)
+	}
+
+	var ImSumAInv mat.Dense
+	if err := ImSumAInv.Inverse(ImSumA); err != nil {
+		return nil, fmt.Errorf("long-run identification requires I - sum(A_j) to be invertible: %v", err)
+	}
+
+	var tmp mat.Dense
+	tmp.Mul(&ImSumAInv, rf.SigmaU)
+	var longRunCov mat.Dense
+	longRunCov.Mul(&tmp, ImSumAInv.T())
+
+	symData := make([]float64, K*K)
+	for i := 0; i < K; i++ {
+		for j := 0; j < K; j++ {
+			symData[i*K+j] = longRunCov.At(i, j)
+		}
+	}
+	longRunSym := mat.NewSymDense(K, symData)
+
+	var chol mat.Cholesky
+	if !chol.Factorize(longRunSym) {
+		return nil, fmt.Errorf("implied long-run covariance is not positive definite")
+	}
+	L := mat.NewTriDense(K, mat.Lower, nil)
+	chol.LTo(L)
+
+	var B mat.Dense
+	B.Mul(ImSumA, L)
+	return &B, nil
+}
+
+// identifyProxy recovers the target structural shock's impact column via
+// external-instrument (proxy-SVAR) identification: regress the reduced-form
+// residuals on the proxy, scale by SigmaU, and normalize to unit shock
+// variance. Only the target column is identified; the rest of B is left as
+// the corresponding Cholesky column so StructuralIRF/FEVD still have a full
+// KxK matrix to work with, but callers should only trust column
+// ProxyShockIndex.
+func identifyProxy(rf *ReducedFormVAR, proxy []float64, shockIndex int) (*mat.Dense, error) {
+	if rf.SigmaU == nil {
+		return nil, fmt.Errorf("SigmaU not available")
+	}
+	K := rf.SigmaU.SymmetricDim()
+	if shockIndex < 0 || shockIndex >= K {
+		return nil, fmt.Errorf("ProxyShockIndex must be between 0 and %d", K-1)
+	}
+
+	U, err := residuals(rf)
+	if err != nil {
+		return nil, err
+	}
+	Treg, _ := U.Dims()
+	if len(proxy) != Treg {
+		return nil, fmt.Errorf("proxy series length %d does not match %d fitted residual rows", len(proxy), Treg)
+	}
+
+	Z := mat.NewVecDense(Treg, proxy)
+
+	var ZtU mat.VecDense
+	ZtU.MulVec(U.T(), Z) // K x 1: Z'U transposed into a column
+
+	ztz := mat.Dot(Z, Z)
+	if ztz == 0 {
+		return nil, fmt.Errorf("proxy series has zero variance")
+	}
+
+	raw := mat.NewVecDense(K, nil)
+	for i := 0; i < K; i++ {
+		raw.SetVec(i, ZtU.AtVec(i)/ztz)
+	}
+
+	var targetCol mat.VecDense
+	targetCol.MulVec(rf.SigmaU, raw) // SigmaU * (Z'U)(Z'Z)^-1
+
+	var SigmaUInv mat.Dense
+	if err := SigmaUInv.Inverse(rf.SigmaU); err != nil {
+		return nil, fmt.Errorf("SigmaU not invertible: %v", err)
+	}
+	var tmp mat.VecDense
+	tmp.MulVec(&SigmaUInv, &targetCol)
+	scaleSq := mat.Dot(&targetCol, &tmp)
+	if scaleSq <= 0 {
+		return nil, fmt.Errorf("proxy-identified column has non-positive implied variance")
+	}
+	scale := math.Sqrt(scaleSq)
+
+	var chol mat.Cholesky
+	if !chol.Factorize(rf.SigmaU) {
+		return nil, fmt.Errorf("SigmaU is not positive definite")
+	}
+	L := mat.NewTriDense(K, mat.Lower, nil)
+	chol.LTo(L)
+
+	B := mat.NewDense(K, K, nil)
+	for i := 0; i < K; i++ {
+		for j := 0; j < K; j++ {
+			B.Set(i, j, L.At(i, j))
+		}
+		B.Set(i, shockIndex, targetCol.AtVec(i)/scale)
+	}
+
+	return B, nil
+}
+
+// residuals returns the fitted reduced-form residuals U (Treg x K) cached by
+// the estimator. Used by identifyProxy since proxy-SVAR needs the actual
+// fitted residuals, not just their covariance SigmaU.
+func residuals(rf *ReducedFormVAR) (*mat.Dense, error) {
+	if rf.residualsCache == nil {
+		return nil, fmt.Errorf("residuals not available: model must be re-estimated with this version of the package")
+	}
+	return rf.residualsCache, nil
+}
+
+// identifySignRestrictions draws random orthogonal rotations of the Cholesky
+// factor of SigmaU, keeps those whose impact responses satisfy opts.SignPattern,
+// and returns the accepted rotation closest to the median impact response of
+// TargetVariable to TargetShock (the "median-target" rule, which avoids the
+// incoherent pointwise-median-IRF some sign-restricted VAR implementations use).
+func identifySignRestrictions(SigmaU *mat.SymDense, opts StructuralOptions) (*mat.Dense, error) {
+	K := SigmaU.SymmetricDim()
+	if len(opts.SignPattern) != K {
+		return nil, fmt.Errorf("SignPattern must be %dx%d", K, K)
+	}
+
+	var chol mat.Cholesky
+	if !chol.Factorize(SigmaU) {
+		return nil, fmt.Errorf("SigmaU is not positive definite")
+	}
+	L := mat.NewTriDense(K, mat.Lower, nil)
+	chol.LTo(L)
+
+	nDraws := opts.NumDraws
+	if nDraws <= 0 {
+		nDraws = 1000
+	}
+
+	rng := rand.New(rand.NewSource(opts.Seed))
+	if opts.Seed == 0 {
+		rng = rand.New(rand.NewSource(1))
+	}
+
+	type accepted struct {
+		B      *mat.Dense
+		target float64
+	}
+	var accepts []accepted
+
+	for d := 0; d < nDraws; d++ {
+		Q, err := randomOrthogonal(K, rng)
+		if err != nil {
+			continue
+		}
+
+		var B mat.Dense
+		B.Mul(L, Q)
+
+		if !matchesSignPattern(&B, opts.SignPattern) {
+			continue
+		}
+
+		accepts = append(accepts, accepted{B: mat.DenseCopyOf(&B), target: B.At(opts.TargetVariable, opts.TargetShock)})
+	}
+
+	if len(accepts) == 0 {
+		return nil, fmt.Errorf("no rotation among %d draws satisfied the sign pattern", nDraws)
+	}
+
+	targets := make([]float64, len(accepts))
+	for i, a := range accepts {
+		targets[i] = a.target
+	}
+	sort.Float64s(targets)
+	median := targets[len(targets)/2]
+
+	best := accepts[0]
+	bestDist := absF(best.target - median)
+	for _, a := range accepts[1:] {
+		d := absF(a.target - median)
+		if d < bestDist {
+			best, bestDist = a, d
+		}
+	}
+
+	return best.B, nil
+}
+
+// randomOrthogonal draws a KxK matrix uniformly from the orthogonal group via
+// QR decomposition of a Gaussian matrix, with the sign of R's diagonal folded
+// into Q so the draw is Haar-uniform rather than biased toward one orthant.
+func randomOrthogonal(K int, rng *rand.Rand) (*mat.Dense, error) {
+	data := make([]float64, K*K)
+	for i := range data {
+		data[i] = rng.NormFloat64()
+	}
+	G := mat.NewDense(K, K, data)
+
+	var qr mat.QR
+	qr.Factorize(G)
+
+	var Q mat.Dense
+	qr.QTo(&Q)
+	var R mat.Dense
+	qr.RTo(&R)
+
+	for j := 0; j < K; j++ {
+		if R.At(j, j) < 0 {
+			for i := 0; i < K; i++ {
+				Q.Set(i, j, -Q.At(i, j))
+			}
+		}
+	}
+
+	return &Q, nil
+}
+
+// matchesSignPattern checks B's entries against pattern (-1/0/1); 0 means unrestricted.
+func matchesSignPattern(B *mat.Dense, pattern [][]int) bool {
+	K, _ := B.Dims()
+	for i := 0; i < K; i++ {
+		for j := 0; j < K; j++ {
+			switch pattern[i][j] {
+			case 1:
+				if B.At(i, j) < 0 {
+					return false
+				}
+			case -1:
+				if B.At(i, j) > 0 {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+func absF(x float64) float64 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
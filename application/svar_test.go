@@ -0,0 +1,315 @@
+package main
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestIdentify_ShortRunPattern_RecoversSigmaU(t *testing.T) {
+	spec := ModelSpec{Lags: 1, Deterministic: DetNone}
+	A1 := mat.NewDense(2, 2, []float64{0.3, 0.1, 0.0, 0.4})
+	SigmaU := mat.NewSymDense(2, []float64{
+		1.0, 0.2,
+		0.2, 1.0,
+	})
+	rf := &ReducedFormVAR{Model: spec, A: []*mat.Dense{A1}, SigmaU: SigmaU}
+
+	pattern := [][]float64{
+		{math.NaN(), 0},
+		{math.NaN(), math.NaN()},
+	}
+	sv, err := rf.Identify(StructuralOptions{Scheme: SchemeShortRunPattern, Pattern: pattern})
+	if err != nil {
+		t.Fatalf("Identify returned error: %v", err)
+	}
+
+	if got := sv.B.At(0, 1); !almostEqual(got, 0, 1e-8) {
+		t.Errorf("B[0][1] = %v, want fixed at 0", got)
+	}
+
+	var BBt mat.Dense
+	BBt.Mul(sv.B, sv.B.T())
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 2; j++ {
+			if !almostEqual(BBt.At(i, j), SigmaU.At(i, j), 1e-6) {
+				t.Errorf("(B*B')[%d][%d] = %v, want %v", i, j, BBt.At(i, j), SigmaU.At(i, j))
+			}
+		}
+	}
+}
+
+func TestIdentify_ShortRunPattern_RejectsWrongFreeCount(t *testing.T) {
+	spec := ModelSpec{Lags: 1, Deterministic: DetNone}
+	A1 := mat.NewDense(2, 2, []float64{0.3, 0.1, 0.0, 0.4})
+	SigmaU := mat.NewSymDense(2, []float64{1.0, 0.2, 0.2, 1.0})
+	rf := &ReducedFormVAR{Model: spec, A: []*mat.Dense{A1}, SigmaU: SigmaU}
+
+	pattern := [][]float64{
+		{math.NaN(), math.NaN()},
+		{math.NaN(), math.NaN()},
+	}
+	if _, err := rf.Identify(StructuralOptions{Scheme: SchemeShortRunPattern, Pattern: pattern}); err == nil {
+		t.Fatalf("expected an error when free-entry count does not match K(K+1)/2")
+	}
+}
+
+func TestIdentify_LongRun_RecoversSigmaUAndIsLowerTriangularInTheLimit(t *testing.T) {
+	spec := ModelSpec{Lags: 1, Deterministic: DetNone}
+	A1 := mat.NewDense(2, 2, []float64{0.3, 0.1, 0.0, 0.4})
+	SigmaU := mat.NewSymDense(2, []float64{
+		1.0, 0.2,
+		0.2, 1.0,
+	})
+	rf := &ReducedFormVAR{Model: spec, A: []*mat.Dense{A1}, SigmaU: SigmaU}
+
+	sv, err := rf.Identify(StructuralOptions{Scheme: SchemeLongRun})
+	if err != nil {
+		t.Fatalf("Identify returned error: %v", err)
+	}
+
+	var BBt mat.Dense
+	BBt.Mul(sv.B, sv.B.T())
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 2; j++ {
+			if !almostEqual(BBt.At(i, j), SigmaU.At(i, j), 1e-8) {
+				t.Errorf("(B*B')[%d][%d] = %v, want %v", i, j, BBt.At(i, j), SigmaU.At(i, j))
+			}
+		}
+	}
+
+	// The long-run multiplier Xi = (I - A1)^-1 * B must be lower-triangular.
+	K, _ := A1.Dims()
+	ImA := mat.NewDense(K, K, nil)
+	for i := 0; i < K; i++ {
+		ImA.Set(i, i, 1.0)
+	}
+	ImA.Sub(ImA, A1)
+	var ImAInv mat.Dense
+	if err := ImAInv.Inverse(ImA); err != nil {
+		t.Fatalf("I - A1 not invertible: %v", err)
+	}
+	var Xi mat.Dense
+	Xi.Mul(&ImAInv, sv.B)
+	if got := Xi.At(0, 1); !almostEqual(got, 0, 1e-6) {
+		t.Errorf("long-run multiplier (0,1) = %v, want ~0 (lower-triangular)", got)
+	}
+}
+
+func TestIdentify_ShortRun_RecoversSigmaUWithRecursiveZeroMask(t *testing.T) {
+	spec := ModelSpec{Lags: 1, Deterministic: DetNone}
+	A1 := mat.NewDense(2, 2, []float64{0.3, 0.1, 0.0, 0.4})
+	SigmaU := mat.NewSymDense(2, []float64{
+		1.0, 0.2,
+		0.2, 1.0,
+	})
+	rf := &ReducedFormVAR{Model: spec, A: []*mat.Dense{A1}, SigmaU: SigmaU}
+
+	sv, err := rf.Identify(StructuralOptions{Scheme: SchemeShortRun, ZeroMask: RecursiveZeroMask(2)})
+	if err != nil {
+		t.Fatalf("Identify returned error: %v", err)
+	}
+
+	if got := sv.B.At(0, 1); !almostEqual(got, 0, 1e-8) {
+		t.Errorf("B[0][1] = %v, want fixed at 0 under the recursive mask", got)
+	}
+
+	var BBt mat.Dense
+	BBt.Mul(sv.B, sv.B.T())
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 2; j++ {
+			if !almostEqual(BBt.At(i, j), SigmaU.At(i, j), 1e-6) {
+				t.Errorf("(B*B')[%d][%d] = %v, want %v", i, j, BBt.At(i, j), SigmaU.At(i, j))
+			}
+		}
+	}
+}
+
+func TestIdentify_ShortRun_NonTrivialMaskExercisesGaussNewtonIteration(t *testing.T) {
+	spec := ModelSpec{Lags: 1, Deterministic: DetNone}
+	A1 := mat.NewDense(2, 2, []float64{0.3, 0.1, 0.0, 0.4})
+	SigmaU := mat.NewSymDense(2, []float64{
+		1.0, 0.2,
+		0.2, 1.0,
+	})
+	rf := &ReducedFormVAR{Model: spec, A: []*mat.Dense{A1}, SigmaU: SigmaU}
+
+	// Zeroing B[1][0] (upper-triangular) isn't the Cholesky factor's natural
+	// zero pattern (that would be B[0][1]), so the Cholesky-derived starting
+	// guess doesn't already satisfy B*B'=SigmaU here and gaussNewtonRefineB
+	// has to do real work to converge.
+	mask := [][]bool{
+		{false, false},
+		{true, false},
+	}
+	sv, err := rf.Identify(StructuralOptions{Scheme: SchemeShortRun, ZeroMask: mask})
+	if err != nil {
+		t.Fatalf("Identify returned error: %v", err)
+	}
+
+	if got := sv.B.At(1, 0); !almostEqual(got, 0, 1e-6) {
+		t.Errorf("B[1][0] = %v, want fixed at 0", got)
+	}
+
+	var BBt mat.Dense
+	BBt.Mul(sv.B, sv.B.T())
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 2; j++ {
+			if !almostEqual(BBt.At(i, j), SigmaU.At(i, j), 1e-6) {
+				t.Errorf("(B*B')[%d][%d] = %v, want %v", i, j, BBt.At(i, j), SigmaU.At(i, j))
+			}
+		}
+	}
+}
+
+func TestIdentify_ShortRunPattern_ReturnsErrorWhenPatternIsInfeasible(t *testing.T) {
+	spec := ModelSpec{Lags: 1, Deterministic: DetNone}
+	A1 := mat.NewDense(2, 2, []float64{0.3, 0.1, 0.0, 0.4})
+	SigmaU := mat.NewSymDense(2, []float64{1.0, 0.2, 0.2, 1.0})
+	rf := &ReducedFormVAR{Model: spec, A: []*mat.Dense{A1}, SigmaU: SigmaU}
+
+	// B[0][0] fixed at 100 makes row 0's equation B[0][0]^2+B[0][1]^2=SigmaU[0][0]
+	// unsatisfiable for any real B[0][1], so gaussNewtonRefineB can never drive
+	// the residual below tolerance and must report non-convergence.
+	pattern := [][]float64{
+		{100, math.NaN()},
+		{math.NaN(), math.NaN()},
+	}
+	if _, err := rf.Identify(StructuralOptions{Scheme: SchemeShortRunPattern, Pattern: pattern}); err == nil {
+		t.Fatalf("expected a non-convergence error for an infeasible fixed pattern")
+	}
+}
+
+func TestIdentify_ShortRun_RejectsWrongMaskDims(t *testing.T) {
+	spec := ModelSpec{Lags: 1, Deterministic: DetNone}
+	A1 := mat.NewDense(2, 2, []float64{0.3, 0.1, 0.0, 0.4})
+	SigmaU := mat.NewSymDense(2, []float64{1.0, 0.2, 0.2, 1.0})
+	rf := &ReducedFormVAR{Model: spec, A: []*mat.Dense{A1}, SigmaU: SigmaU}
+
+	badMask := [][]bool{{false, false, false}}
+	if _, err := rf.Identify(StructuralOptions{Scheme: SchemeShortRun, ZeroMask: badMask}); err == nil {
+		t.Fatalf("expected an error for a ZeroMask whose dimensions don't match SigmaU")
+	}
+}
+
+func TestIdentify_Proxy_TargetColumnHasUnitImpliedVariance(t *testing.T) {
+	spec := ModelSpec{Lags: 1, Deterministic: DetNone}
+	A1 := mat.NewDense(2, 2, []float64{0.3, 0.1, 0.0, 0.4})
+	SigmaU := mat.NewSymDense(2, []float64{
+		1.0, 0.5,
+		0.5, 1.25,
+	})
+	// U = E*B' for the true impact matrix B = [[1,0],[0.5,1]] (SigmaU = B*B')
+	// and an orthogonal structural-shock design E whose column 0 is exactly
+	// the proxy below, so Z is a perfect (noiseless) instrument for shock 0.
+	U := mat.NewDense(4, 2, []float64{
+		1, 1.5,
+		1, -0.5,
+		-1, 0.5,
+		-1, -1.5,
+	})
+	proxy := []float64{1, 1, -1, -1}
+
+	rf := &ReducedFormVAR{Model: spec, A: []*mat.Dense{A1}, SigmaU: SigmaU, residualsCache: U}
+
+	sv, err := rf.Identify(StructuralOptions{Scheme: SchemeProxy, Proxy: proxy, ProxyShockIndex: 0})
+	if err != nil {
+		t.Fatalf("Identify returned error: %v", err)
+	}
+
+	col := mat.NewVecDense(2, []float64{sv.B.At(0, 0), sv.B.At(1, 0)})
+	var SigmaUInv mat.Dense
+	if err := SigmaUInv.Inverse(SigmaU); err != nil {
+		t.Fatalf("SigmaU not invertible: %v", err)
+	}
+	var tmp mat.VecDense
+	tmp.MulVec(&SigmaUInv, col)
+	impliedVar := mat.Dot(col, &tmp)
+	if !almostEqual(impliedVar, 1.0, 1e-6) {
+		t.Errorf("identified column's implied variance (col' SigmaU^-1 col) = %v, want 1.0", impliedVar)
+	}
+}
+
+func TestIdentify_Proxy_RejectsMismatchedProxyLength(t *testing.T) {
+	spec := ModelSpec{Lags: 1, Deterministic: DetNone}
+	A1 := mat.NewDense(2, 2, []float64{0.3, 0.1, 0.0, 0.4})
+	SigmaU := mat.NewSymDense(2, []float64{1.0, 0.5, 0.5, 1.25})
+	U := mat.NewDense(4, 2, []float64{1, 1.5, 1, -0.5, -1, 0.5, -1, -1.5})
+	rf := &ReducedFormVAR{Model: spec, A: []*mat.Dense{A1}, SigmaU: SigmaU, residualsCache: U}
+
+	if _, err := rf.Identify(StructuralOptions{Scheme: SchemeProxy, Proxy: []float64{1, 1, -1}, ProxyShockIndex: 0}); err == nil {
+		t.Fatalf("expected an error when the proxy series length does not match the residual sample size")
+	}
+}
+
+func TestIdentify_SignRestrictions_SatisfiesPatternAndRecoversSigmaU(t *testing.T) {
+	spec := ModelSpec{Lags: 1, Deterministic: DetNone}
+	A1 := mat.NewDense(2, 2, []float64{0.3, 0.1, 0.0, 0.4})
+	SigmaU := mat.NewSymDense(2, []float64{
+		1.0, 0.2,
+		0.2, 1.0,
+	})
+	rf := &ReducedFormVAR{Model: spec, A: []*mat.Dense{A1}, SigmaU: SigmaU}
+
+	pattern := [][]int{
+		{1, 0},
+		{0, 1},
+	}
+	sv, err := rf.Identify(StructuralOptions{
+		Scheme:      SchemeSignRestrictions,
+		SignPattern: pattern,
+		NumDraws:    200,
+		Seed:        42,
+	})
+	if err != nil {
+		t.Fatalf("Identify returned error: %v", err)
+	}
+
+	if !matchesSignPattern(sv.B, pattern) {
+		t.Errorf("returned B = %v does not satisfy sign pattern %v", sv.B, pattern)
+	}
+
+	var BBt mat.Dense
+	BBt.Mul(sv.B, sv.B.T())
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 2; j++ {
+			if !almostEqual(BBt.At(i, j), SigmaU.At(i, j), 1e-8) {
+				t.Errorf("(B*B')[%d][%d] = %v, want %v", i, j, BBt.At(i, j), SigmaU.At(i, j))
+			}
+		}
+	}
+}
+
+func TestIdentify_SignRestrictions_RejectsWrongPatternDims(t *testing.T) {
+	spec := ModelSpec{Lags: 1, Deterministic: DetNone}
+	A1 := mat.NewDense(2, 2, []float64{0.3, 0.1, 0.0, 0.4})
+	SigmaU := mat.NewSymDense(2, []float64{1.0, 0.2, 0.2, 1.0})
+	rf := &ReducedFormVAR{Model: spec, A: []*mat.Dense{A1}, SigmaU: SigmaU}
+
+	badMask := [][]int{{1}}
+	if _, err := rf.Identify(StructuralOptions{Scheme: SchemeSignRestrictions, SignPattern: badMask, NumDraws: 50, Seed: 1}); err == nil {
+		t.Fatalf("expected an error for a SignPattern whose dimensions don't match SigmaU")
+	}
+}
+
+func TestIdentify_LongRun_StructuralIRFRunsToHorizon(t *testing.T) {
+	spec := ModelSpec{Lags: 1, Deterministic: DetNone}
+	A1 := mat.NewDense(2, 2, []float64{0.3, 0.1, 0.0, 0.4})
+	SigmaU := mat.NewSymDense(2, []float64{1.0, 0.2, 0.2, 1.0})
+	rf := &ReducedFormVAR{Model: spec, A: []*mat.Dense{A1}, SigmaU: SigmaU}
+
+	sv, err := rf.Identify(StructuralOptions{Scheme: SchemeLongRun})
+	if err != nil {
+		t.Fatalf("Identify returned error: %v", err)
+	}
+
+	irf, err := sv.StructuralIRF(6, 0)
+	if err != nil {
+		t.Fatalf("StructuralIRF returned error: %v", err)
+	}
+	horizon, _ := irf.Dims()
+	if horizon != 6 {
+		t.Errorf("expected 6 horizons, got %d", horizon)
+	}
+}